@@ -17,21 +17,213 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// Condition types reported in NodeLabelPolicyStatus
+const (
+	// ConditionReady indicates the policy's labels are applied to the desired number of nodes
+	ConditionReady = "Ready"
+	// ConditionProgressing indicates the policy's labels moved between nodes during the last reconciliation
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded indicates the policy could not be fully enforced
+	ConditionDegraded = "Degraded"
+	// ConditionSuspended indicates the policy is not reconciled
+	ConditionSuspended = "Suspended"
+)
+
+// Condition reasons reported in NodeLabelPolicyStatus
+const (
+	ReasonReconciled                = "Reconciled"
+	ReasonSelectionChanged          = "SelectionChanged"
+	ReasonInsufficientEligibleNodes = "InsufficientEligibleNodes"
+	ReasonNodeUpdateFailed          = "NodeUpdateFailed"
+	ReasonLabelConflict             = "LabelConflict"
+	ReasonPolicyConflict            = "PolicyConflict"
+	ReasonDryRun                    = "DryRun"
+	ReasonSuspended                 = "Suspended"
+	ReasonRollingOut                = "RollingOut"
+)
+
+// Deletion policies deciding what happens to a policy's labels when the policy is deleted
+const (
+	// DeletionPolicyDelete removes the policy's labels from all nodes
+	DeletionPolicyDelete = "Delete"
+	// DeletionPolicyOrphan leaves the policy's labels on the nodes and only stops tracking them
+	DeletionPolicyOrphan = "Orphan"
+)
+
 // NodeLabelPolicyStrategy defines the strategy for selecting nodes
 type NodeLabelPolicyStrategy struct {
-	// Type specifies the selection strategy type
-	// +kubebuilder:validation:Enum=oldest;newest;random
-	Type string `json:"type"`
+	// Type specifies the selection strategy type.
+	// Defaults to oldest.
+	// +kubebuilder:validation:Enum=oldest;newest;random;spread;score
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Count specifies the number of nodes to select, either as an absolute number
+	// or as a percentage of the eligible nodes (e.g. "25%").
+	// Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == int ? self >= 1 : self.matches('^([1-9][0-9]?|100)%$')",message="must be greater than 0 or a percentage between 1% and 100%"
+	// +optional
+	Count *intstr.IntOrString `json:"count,omitempty"`
+
+	// Rounding specifies how a percentage count is rounded to a whole number of nodes.
+	// Defaults to up.
+	// +kubebuilder:validation:Enum=up;down
+	// +optional
+	Rounding string `json:"rounding,omitempty"`
+
+	// MinCount is the lower bound applied to the resolved count
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCount *int32 `json:"minCount,omitempty"`
+
+	// MaxCount is the upper bound applied to the resolved count
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// TopologyKey is the node label whose values define the domains the spread strategy
+	// distributes nodes across and the zones the score strategy weights. Defaults to topology.kubernetes.io/zone.
+	// The spread strategy selects nodes without this label only after the nodes of all domains.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// TieBreaker orders the nodes within a topology domain for the spread strategy.
+	// Defaults to oldest.
+	// +kubebuilder:validation:Enum=oldest;newest
+	// +optional
+	TieBreaker string `json:"tieBreaker,omitempty"`
+
+	// Stickiness controls whether nodes already carrying this policy's labels keep them
+	// while they remain eligible. With sticky, the strategy only fills the shortfall.
+	// Defaults to none.
+	// +kubebuilder:validation:Enum=none;sticky
+	// +optional
+	Stickiness string `json:"stickiness,omitempty"`
+
+	// Score defines the weights the score strategy ranks nodes by
+	// +optional
+	Score *NodeScoring `json:"score,omitempty"`
+}
+
+// NodeScoring ranks nodes for the score strategy by the weighted sum of their attributes.
+// Allocatable CPU and memory, age and label values are scaled to [0, 1] between the lowest and highest
+// value among the eligible nodes before they are weighted, and zone and label matches count as 1, so weights
+// are comparable across attributes. Negative weights favor low values. Nodes with the highest score are
+// selected first, and nodes with the same score are ordered by name.
+type NodeScoring struct {
+	// CPU weights the allocatable CPU of the node
+	// +optional
+	CPU int32 `json:"cpu,omitempty"`
+
+	// Memory weights the allocatable memory of the node
+	// +optional
+	Memory int32 `json:"memory,omitempty"`
+
+	// Age weights the age of the node, so a positive weight favors older nodes
+	// +optional
+	Age int32 `json:"age,omitempty"`
+
+	// LabelValues weight the numeric value of node labels.
+	// Nodes without the label or with a non-numeric value score as the lowest value.
+	// +optional
+	LabelValues []NodeLabelValueWeight `json:"labelValues,omitempty"`
+
+	// Zones weight nodes by the value of their topology key label
+	// +optional
+	Zones []NodeZoneWeight `json:"zones,omitempty"`
+
+	// Labels weight nodes carrying a label
+	// +optional
+	Labels []NodeLabelWeight `json:"labels,omitempty"`
+}
+
+// NodeLabelValueWeight weights the numeric value of a node label
+type NodeLabelValueWeight struct {
+	// Key is the label key
+	Key string `json:"key"`
+
+	// Weight is the weight of the label value
+	Weight int32 `json:"weight"`
+}
+
+// NodeZoneWeight weights nodes in a zone
+type NodeZoneWeight struct {
+	// Zone is the value of the topology key label
+	Zone string `json:"zone"`
+
+	// Weight is added to the score of the nodes in the zone
+	Weight int32 `json:"weight"`
+}
+
+// NodeLabelWeight weights nodes carrying a label and, optionally, a value
+type NodeLabelWeight struct {
+	// Key is the label key
+	Key string `json:"key"`
+
+	// Value is the label value to match. An empty value matches all values.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Weight is added to the score of the nodes carrying the label
+	Weight int32 `json:"weight"`
+}
+
+// NodeEligibility defines which Ready nodes a policy may select
+type NodeEligibility struct {
+	// ExcludeUnschedulable excludes cordoned nodes, i.e. nodes with spec.unschedulable set
+	// +optional
+	ExcludeUnschedulable bool `json:"excludeUnschedulable,omitempty"`
+
+	// ExcludeTaints excludes nodes carrying a taint matching any of these selectors.
+	// Selectors matching a taint the policy adds itself are rejected.
+	// +optional
+	ExcludeTaints []NodeTaintSelector `json:"excludeTaints,omitempty"`
+
+	// ExcludeConditions excludes nodes reporting any of these condition types with status True,
+	// e.g. MemoryPressure, DiskPressure or PIDPressure
+	// +optional
+	ExcludeConditions []corev1.NodeConditionType `json:"excludeConditions,omitempty"`
 
-	// Count specifies the number of nodes to select
-	// +kubebuilder:validation:Minimum=1
-	Count int32 `json:"count"`
+	// MinNodeAge excludes nodes created less than this long ago
+	// +optional
+	MinNodeAge *metav1.Duration `json:"minNodeAge,omitempty"`
+}
+
+// NodeTaintSelector matches node taints by key and, optionally, effect
+type NodeTaintSelector struct {
+	// Key is the taint key to match
+	Key string `json:"key"`
+
+	// Effect is the taint effect to match. An empty effect matches all effects.
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+}
+
+// NodeLabelPolicyRollout limits how fast the policy's labels move between nodes when the selection changes
+type NodeLabelPolicyRollout struct {
+	// MaxSurge is how many nodes above the desired count may carry the policy's labels while they move,
+	// either as an absolute number or as a percentage of the desired count rounded up.
+	// Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is how many nodes below the desired count may carry the policy's labels while they move,
+	// either as an absolute number or as a percentage of the desired count rounded down.
+	// Defaults to 0.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // NodeLabelPolicySpec defines the desired state of NodeLabelPolicy.
@@ -43,7 +235,106 @@ type NodeLabelPolicySpec struct {
 	Strategy NodeLabelPolicyStrategy `json:"strategy"`
 
 	// Labels defines the labels to be applied to selected nodes
+	// A value containing "{{" is a Go template rendered per node with .Node.Name, .Node.Labels, .Node.Annotations
+	// and .Index, a number from 0 to N-1 a selected node keeps while it stays selected and below N.
 	Labels map[string]string `json:"labels"`
+
+	// Annotations defines the annotations to be applied to selected nodes and removed from deselected nodes.
+	// Annotations a node already carries that were not set by this policy are left alone.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Taints are added to the selected nodes and removed from nodes that are deselected, along with the labels.
+	// Only taints the policy added are removed; a taint with the same key and effect that was added by
+	// someone else is left alone.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// Selector restricts the nodes considered by the strategy to those matching it.
+	// Nodes that stop matching the selector lose this policy's labels.
+	// An empty or omitted selector matches all nodes.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Eligibility excludes Ready nodes the strategy should not choose from, such as cordoned,
+	// tainted, pressured or freshly created nodes. Selected nodes that become ineligible lose this policy's labels.
+	// +optional
+	Eligibility *NodeEligibility `json:"eligibility,omitempty"`
+
+	// NotReadyToleration is how long a selected node keeps this policy's labels while it is NotReady,
+	// measured from the last transition of its Ready condition. Nodes that are not selected yet must
+	// be Ready to be selected. Unset or zero deselects NotReady nodes immediately.
+	// +optional
+	NotReadyToleration *metav1.Duration `json:"notReadyToleration,omitempty"`
+
+	// MinSelectionDuration is how long a node keeps this policy's labels at least once it is labeled, even when
+	// the strategy would now choose another node. The node is still deselected when it becomes ineligible or
+	// the desired count drops below the number of such nodes. The time a node was labeled is recorded in its
+	// nlp.<policy>/selected-at annotation. Unset or zero lets the strategy move the labels at any time.
+	// +optional
+	MinSelectionDuration *metav1.Duration `json:"minSelectionDuration,omitempty"`
+
+	// Rollout moves the policy's labels gradually when the selection changes: newly selected nodes are
+	// labeled before deselected nodes are unlabeled, within the surge and unavailability limits, and the
+	// remaining moves are made in later reconciliations. Unset moves all labels in a single reconciliation.
+	// +optional
+	Rollout *NodeLabelPolicyRollout `json:"rollout,omitempty"`
+
+	// Priority decides which policy wins when several policies set the same label key to different values on a node.
+	// The policy with the higher priority wins, ties are broken by the lexicographically smaller policy name.
+	// The losing policy leaves the conflicting keys on that node to the winner.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// DryRun makes the controller compute the selection and report the planned label changes
+	// in status.plan without changing any node. Nodes already carrying this policy's labels keep them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Suspend stops the controller from reconciling the policy while leaving its labels on the nodes
+	// that carry them. Deleting a suspended policy still removes its labels.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// ReleaseOnSuspend removes the policy's labels from all nodes while the policy is suspended
+	// +optional
+	ReleaseOnSuspend bool `json:"releaseOnSuspend,omitempty"`
+
+	// DeletionPolicy decides what happens to the policy's labels when the policy is deleted.
+	// Delete removes them from all nodes, Orphan leaves them in place and only removes the
+	// managed-by label so the nodes are no longer tracked by the policy.
+	// Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// NodeLabelPolicyPlan lists node changes the controller has yet to make to enforce a policy
+type NodeLabelPolicyPlan struct {
+	// NodesToLabel are the selected nodes the policy's labels are to be applied to
+	NodesToLabel []string `json:"nodesToLabel,omitempty"`
+
+	// NodesToUnlabel are the nodes that are to lose the policy's labels
+	NodesToUnlabel []string `json:"nodesToUnlabel,omitempty"`
+}
+
+// NodeExclusions counts the nodes excluded from selection by each eligibility filter
+// A node failing several filters is counted under the first one, in the order of the fields
+type NodeExclusions struct {
+	// NotReady is the number of nodes excluded because they are not Ready
+	NotReady int32 `json:"notReady,omitempty"`
+
+	// Unschedulable is the number of cordoned nodes excluded
+	Unschedulable int32 `json:"unschedulable,omitempty"`
+
+	// Tainted is the number of nodes excluded because of their taints
+	Tainted int32 `json:"tainted,omitempty"`
+
+	// Conditions is the number of nodes excluded because of their conditions
+	Conditions int32 `json:"conditions,omitempty"`
+
+	// TooYoung is the number of nodes excluded because they are younger than the minimum node age
+	TooYoung int32 `json:"tooYoung,omitempty"`
 }
 
 // NodeLabelPolicyStatus defines the observed state of NodeLabelPolicy.
@@ -54,13 +345,53 @@ type NodeLabelPolicyStatus struct {
 	// SelectedNodes contains the list of node names that currently have this policy's labels
 	SelectedNodes []string `json:"selectedNodes,omitempty"`
 
+	// DesiredCount is the number of nodes the strategy resolved to during the last reconciliation
+	DesiredCount int32 `json:"desiredCount,omitempty"`
+
+	// EligibleCount is the number of nodes the strategy could choose from during the last reconciliation
+	EligibleCount int32 `json:"eligibleCount,omitempty"`
+
+	// ExcludedNodes counts the nodes each eligibility filter excluded during the last reconciliation
+	// +optional
+	ExcludedNodes *NodeExclusions `json:"excludedNodes,omitempty"`
+
+	// SelectedCount is the number of nodes that currently have this policy's labels
+	SelectedCount int32 `json:"selectedCount,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the policy's state
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Plan lists the node changes planned during the last reconciliation of a dry-run policy
+	// +optional
+	Plan *NodeLabelPolicyPlan `json:"plan,omitempty"`
+
+	// Rollout lists the label moves the rollout limits held back during the last reconciliation,
+	// to be made in later reconciliations. It is empty once the labels are on the selected nodes only.
+	// +optional
+	Rollout *NodeLabelPolicyPlan `json:"rollout,omitempty"`
+
 	// LastReconcileTime is the timestamp of the last successful reconciliation
 	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,shortName=nlp
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.strategy.type`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredCount`
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.status.selectedCount`
+// +kubebuilder:printcolumn:name="Eligible",type=integer,JSONPath=`.status.eligibleCount`
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`,priority=1
+// +kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`,priority=1
+// +kubebuilder:printcolumn:name="DryRun",type=boolean,JSONPath=`.spec.dryRun`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // NodeLabelPolicy is the Schema for the nodelabelpolicies API.
 type NodeLabelPolicy struct {
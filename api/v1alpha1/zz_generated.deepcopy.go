@@ -21,9 +21,57 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeEligibility) DeepCopyInto(out *NodeEligibility) {
+	*out = *in
+	if in.ExcludeTaints != nil {
+		in, out := &in.ExcludeTaints, &out.ExcludeTaints
+		*out = make([]NodeTaintSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeConditions != nil {
+		in, out := &in.ExcludeConditions, &out.ExcludeConditions
+		*out = make([]v1.NodeConditionType, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinNodeAge != nil {
+		in, out := &in.MinNodeAge, &out.MinNodeAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeEligibility.
+func (in *NodeEligibility) DeepCopy() *NodeEligibility {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeEligibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeExclusions) DeepCopyInto(out *NodeExclusions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeExclusions.
+func (in *NodeExclusions) DeepCopy() *NodeExclusions {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeExclusions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLabelPolicy) DeepCopyInto(out *NodeLabelPolicy) {
 	*out = *in
@@ -83,10 +131,60 @@ func (in *NodeLabelPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelPolicyPlan) DeepCopyInto(out *NodeLabelPolicyPlan) {
+	*out = *in
+	if in.NodesToLabel != nil {
+		in, out := &in.NodesToLabel, &out.NodesToLabel
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodesToUnlabel != nil {
+		in, out := &in.NodesToUnlabel, &out.NodesToUnlabel
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelPolicyPlan.
+func (in *NodeLabelPolicyPlan) DeepCopy() *NodeLabelPolicyPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelPolicyPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelPolicyRollout) DeepCopyInto(out *NodeLabelPolicyRollout) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelPolicyRollout.
+func (in *NodeLabelPolicyRollout) DeepCopy() *NodeLabelPolicyRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelPolicyRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLabelPolicySpec) DeepCopyInto(out *NodeLabelPolicySpec) {
 	*out = *in
-	out.Strategy = in.Strategy
+	in.Strategy.DeepCopyInto(&out.Strategy)
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -94,6 +192,45 @@ func (in *NodeLabelPolicySpec) DeepCopyInto(out *NodeLabelPolicySpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Eligibility != nil {
+		in, out := &in.Eligibility, &out.Eligibility
+		*out = new(NodeEligibility)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotReadyToleration != nil {
+		in, out := &in.NotReadyToleration, &out.NotReadyToleration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinSelectionDuration != nil {
+		in, out := &in.MinSelectionDuration, &out.MinSelectionDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(NodeLabelPolicyRollout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelPolicySpec.
@@ -114,6 +251,28 @@ func (in *NodeLabelPolicyStatus) DeepCopyInto(out *NodeLabelPolicyStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedNodes != nil {
+		in, out := &in.ExcludedNodes, &out.ExcludedNodes
+		*out = new(NodeExclusions)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(NodeLabelPolicyPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(NodeLabelPolicyPlan)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LastReconcileTime != nil {
 		in, out := &in.LastReconcileTime, &out.LastReconcileTime
 		*out = (*in).DeepCopy()
@@ -133,6 +292,26 @@ func (in *NodeLabelPolicyStatus) DeepCopy() *NodeLabelPolicyStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLabelPolicyStrategy) DeepCopyInto(out *NodeLabelPolicyStrategy) {
 	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinCount != nil {
+		in, out := &in.MinCount, &out.MinCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(NodeScoring)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelPolicyStrategy.
@@ -144,3 +323,93 @@ func (in *NodeLabelPolicyStrategy) DeepCopy() *NodeLabelPolicyStrategy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelValueWeight) DeepCopyInto(out *NodeLabelValueWeight) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelValueWeight.
+func (in *NodeLabelValueWeight) DeepCopy() *NodeLabelValueWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelValueWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelWeight) DeepCopyInto(out *NodeLabelWeight) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelWeight.
+func (in *NodeLabelWeight) DeepCopy() *NodeLabelWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeScoring) DeepCopyInto(out *NodeScoring) {
+	*out = *in
+	if in.LabelValues != nil {
+		in, out := &in.LabelValues, &out.LabelValues
+		*out = make([]NodeLabelValueWeight, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]NodeZoneWeight, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]NodeLabelWeight, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeScoring.
+func (in *NodeScoring) DeepCopy() *NodeScoring {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeScoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTaintSelector) DeepCopyInto(out *NodeTaintSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTaintSelector.
+func (in *NodeTaintSelector) DeepCopy() *NodeTaintSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTaintSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeZoneWeight) DeepCopyInto(out *NodeZoneWeight) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeZoneWeight.
+func (in *NodeZoneWeight) DeepCopy() *NodeZoneWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeZoneWeight)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/jivvon/node-label-controller/internal/controller/handlers"
 	"github.com/jivvon/node-label-controller/internal/external/k8s"
 	"github.com/jivvon/node-label-controller/internal/utils"
+	webhookv1alpha1 "github.com/jivvon/node-label-controller/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -214,11 +215,19 @@ func main() {
 	if err := controller.NewNodeLabelPolicyReconciler(
 		k8sClient,
 		nodeLabelPolicyHandler,
+		mgr.GetEventRecorderFor("nodelabelpolicy-controller"),
 		mgr.GetScheme(),
 	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeLabelPolicy")
 		os.Exit(1)
 	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupNodeLabelPolicyWebhookWithManager(mgr, k8sClient); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NodeLabelPolicy")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -21,7 +21,29 @@ package constants
 import "time"
 
 const (
-	ReconcileInterval    = 30 * time.Second
-	FinalizerName        = "nodelabelpolicy.nlp.lento.dev/finalizer"
-	ManagedByLabelPrefix = "nlp"
+	ReconcileInterval     = 30 * time.Second
+	FinalizerName         = "nodelabelpolicy.nlp.lento.dev/finalizer"
+	ManagedByLabelPrefix  = "nlp"
+	DefaultTopologyKey    = "topology.kubernetes.io/zone"
+	DefaultStrategyType   = "oldest"
+	DefaultCount          = 1
+	DefaultRounding       = "up"
+	DefaultTieBreaker     = "oldest"
+	DefaultStickiness     = "none"
+	DefaultDeletionPolicy = "Delete"
+	DefaultMaxSurge       = 1
+	DefaultMaxUnavailable = 0
+	RolloutInterval       = 5 * time.Second
+	EventDedupWindow      = 10 * time.Minute
+)
+
+// Event reasons recorded on NodeLabelPolicies and Nodes
+const (
+	EventReasonLabelsApplied     = "LabelsApplied"
+	EventReasonLabelsRemoved     = "LabelsRemoved"
+	EventReasonLabelsCleanedUp   = "LabelsCleanedUp"
+	EventReasonLabelsOrphaned    = "LabelsOrphaned"
+	EventReasonTaintsApplied     = "TaintsApplied"
+	EventReasonLabelUpdateFailed = "LabelUpdateFailed"
+	EventReasonLabelsTakenOver   = "LabelsTakenOver"
 )
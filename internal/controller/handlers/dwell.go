@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jivvon/node-label-controller/internal/constants"
+)
+
+// SelectedAtAnnotationKey returns the node annotation recording when a policy labeled the node
+func SelectedAtAnnotationKey(policyName string) string {
+	return fmt.Sprintf("%s.%s/selected-at", constants.ManagedByLabelPrefix, policyName)
+}
+
+// selectedAt returns the time the policy labeled the node, or false if the node carries no readable record of it
+func selectedAt(node *corev1.Node, policyName string) (time.Time, bool) {
+	value, ok := node.Annotations[SelectedAtAnnotationKey(policyName)]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// selectedAtAnnotation returns the annotations to apply to a selected node with the time the policy labeled it,
+// keeping the recorded time of a node the policy already labeled and recording now for any other node
+func selectedAtAnnotation(node *corev1.Node, policyName string, annotations map[string]string, now time.Time) map[string]string {
+	at, ok := selectedAt(node, policyName)
+	if !ok || node.Labels[ManagedByLabelKey(policyName)] != managedByLabelValue {
+		at = now
+	}
+
+	withSelectedAt := make(map[string]string, len(annotations)+1)
+	for key, value := range annotations {
+		withSelectedAt[key] = value
+	}
+	withSelectedAt[SelectedAtAnnotationKey(policyName)] = at.UTC().Format(time.RFC3339)
+	return withSelectedAt
+}
+
+// heldNodes returns the eligible nodes the policy labeled less than the minimum selection duration ago, which
+// must stay selected, and the earliest time one of them may be deselected
+func heldNodes(eligibleNodes []corev1.Node, currentNodeNames []string, policyName string, minDuration *metav1.Duration, now time.Time) (map[string]bool, time.Time) {
+	if minDuration == nil || minDuration.Duration <= 0 {
+		return nil, time.Time{}
+	}
+
+	current := make(map[string]bool, len(currentNodeNames))
+	for _, name := range currentNodeNames {
+		current[name] = true
+	}
+
+	held := map[string]bool{}
+	var until time.Time
+	for _, node := range eligibleNodes {
+		at, ok := selectedAt(&node, policyName)
+		if !ok || !current[node.Name] {
+			continue
+		}
+
+		expiry := at.Add(minDuration.Duration)
+		if !now.Before(expiry) {
+			continue
+		}
+		held[node.Name] = true
+		if until.IsZero() || expiry.Before(until) {
+			until = expiry
+		}
+	}
+
+	return held, until
+}
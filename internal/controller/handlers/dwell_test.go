@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+var _ = Describe("Minimum selection duration", func() {
+	const annotationKey = "nlp.test/selected-at"
+
+	var (
+		handler NodeLabelPolicyHandler
+		policy  *nlpv1alpha1.NodeLabelPolicy
+	)
+
+	newNode := func(name string, age time.Duration, selectedAgo time.Duration) corev1.Node {
+		node := testNode(name, age, nil)
+		if selectedAgo > 0 {
+			node = labeledBy(node, "test")
+			node.Annotations = map[string]string{annotationKey: time.Now().Add(-selectedAgo).UTC().Format(time.RFC3339)}
+		}
+		return node
+	}
+
+	BeforeEach(func() {
+		handler = NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{})
+		policy = &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "newest",
+					Count: ptr.To(intstr.FromInt32(1)),
+				},
+				Labels:               map[string]string{"workload": "monitoring"},
+				MinSelectionDuration: &metav1.Duration{Duration: time.Hour},
+			},
+		}
+	})
+
+	Describe("SelectNodes", func() {
+		It("should keep a node labeled less than the minimum selection duration ago", func() {
+			nodes := []corev1.Node{newNode("labeled", 24*time.Hour, 10*time.Minute), newNode("fresh", time.Minute, 0)}
+
+			selection, err := handler.SelectNodes(context.Background(), nodes, policy, []string{"labeled"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"labeled"}))
+			Expect(selection.HeldUntil).NotTo(BeNil())
+			Expect(*selection.HeldUntil).To(BeTemporally("~", time.Now().Add(50*time.Minute), 2*time.Second))
+		})
+
+		It("should let the strategy move the labels once the minimum selection duration passed", func() {
+			nodes := []corev1.Node{newNode("labeled", 24*time.Hour, 2*time.Hour), newNode("fresh", time.Minute, 0)}
+
+			selection, err := handler.SelectNodes(context.Background(), nodes, policy, []string{"labeled"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"fresh"}))
+			Expect(selection.HeldUntil).To(BeNil())
+		})
+
+		It("should deselect a node that became ineligible", func() {
+			labeled := newNode("labeled", 24*time.Hour, 10*time.Minute)
+			labeled.Status.Conditions[0].Status = corev1.ConditionFalse
+
+			selection, err := handler.SelectNodes(context.Background(), []corev1.Node{labeled, newNode("fresh", time.Minute, 0)}, policy, []string{"labeled"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"fresh"}))
+		})
+
+		It("should keep the held nodes the strategy prefers when the desired count drops", func() {
+			nodes := []corev1.Node{
+				newNode("older", 48*time.Hour, 10*time.Minute),
+				newNode("newer", 24*time.Hour, 10*time.Minute),
+				newNode("fresh", time.Minute, 0),
+			}
+
+			selection, err := handler.SelectNodes(context.Background(), nodes, policy, []string{"older", "newer"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"newer"}))
+		})
+
+		It("should ignore the time a node not labeled by the policy carries", func() {
+			stale := newNode("stale", 24*time.Hour, 10*time.Minute)
+			stale.Labels = nil
+
+			selection, err := handler.SelectNodes(context.Background(), []corev1.Node{stale, newNode("fresh", time.Minute, 0)}, policy, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"fresh"}))
+		})
+	})
+
+	Describe("PlanLabelChanges", func() {
+		It("should record the time a node is labeled", func() {
+			plan, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{newNode("fresh", time.Minute, 0)}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(1))
+
+			at, err := time.Parse(time.RFC3339, plan.Apply[0].Annotations[annotationKey])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(at).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+
+		It("should keep the recorded time of a node the policy labeled", func() {
+			labeled := newNode("labeled", 24*time.Hour, 10*time.Minute)
+			policy.Spec.Annotations = map[string]string{"example.com/owner": "platform"}
+
+			plan, err := handler.PlanLabelChanges(policy, []corev1.Node{labeled}, []corev1.Node{labeled}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(1))
+			Expect(plan.Apply[0].Annotations).To(Equal(map[string]string{
+				"example.com/owner": "platform",
+				annotationKey:       labeled.Annotations[annotationKey],
+			}))
+			Expect(policy.Spec.Annotations).NotTo(HaveKey(annotationKey))
+		})
+
+		It("should not record the time without a minimum selection duration", func() {
+			policy.Spec.MinSelectionDuration = nil
+
+			plan, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{newNode("fresh", time.Minute, 0)}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply[0].Annotations).NotTo(HaveKey(annotationKey))
+		})
+	})
+})
@@ -11,21 +11,40 @@ import (
 )
 
 type FakeNodeLabelPolicyHandler struct {
-	ApplyLabelsToNodeStub        func(context.Context, *v1.Node, map[string]string, string) error
+	ApplyLabelsToNodeStub        func(context.Context, *v1.Node, string, map[string]string, map[string]string) (handlers.ApplyResult, error)
 	applyLabelsToNodeMutex       sync.RWMutex
 	applyLabelsToNodeArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1.Node
-		arg3 map[string]string
-		arg4 string
+		arg3 string
+		arg4 map[string]string
+		arg5 map[string]string
 	}
 	applyLabelsToNodeReturns struct {
-		result1 error
+		result1 handlers.ApplyResult
+		result2 error
 	}
 	applyLabelsToNodeReturnsOnCall map[int]struct {
-		result1 error
+		result1 handlers.ApplyResult
+		result2 error
+	}
+	ApplyTaintsToNodeStub        func(context.Context, *v1.Node, string, []v1.Taint) (bool, error)
+	applyTaintsToNodeMutex       sync.RWMutex
+	applyTaintsToNodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.Node
+		arg3 string
+		arg4 []v1.Taint
+	}
+	applyTaintsToNodeReturns struct {
+		result1 bool
+		result2 error
+	}
+	applyTaintsToNodeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
 	}
-	CleanupLabelsFromAllNodesStub        func(context.Context, string, map[string]string) error
+	CleanupLabelsFromAllNodesStub        func(context.Context, string, map[string]string) ([]v1.Node, error)
 	cleanupLabelsFromAllNodesMutex       sync.RWMutex
 	cleanupLabelsFromAllNodesArgsForCall []struct {
 		arg1 context.Context
@@ -33,47 +52,96 @@ type FakeNodeLabelPolicyHandler struct {
 		arg3 map[string]string
 	}
 	cleanupLabelsFromAllNodesReturns struct {
-		result1 error
+		result1 []v1.Node
+		result2 error
 	}
 	cleanupLabelsFromAllNodesReturnsOnCall map[int]struct {
-		result1 error
+		result1 []v1.Node
+		result2 error
+	}
+	DetectPolicyConflictsStub        func(context.Context, *v1alpha1.NodeLabelPolicy, []v1.Node) (handlers.PolicyConflicts, error)
+	detectPolicyConflictsMutex       sync.RWMutex
+	detectPolicyConflictsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.NodeLabelPolicy
+		arg3 []v1.Node
+	}
+	detectPolicyConflictsReturns struct {
+		result1 handlers.PolicyConflicts
+		result2 error
+	}
+	detectPolicyConflictsReturnsOnCall map[int]struct {
+		result1 handlers.PolicyConflicts
+		result2 error
 	}
-	RemoveLabelsFromUnselectedNodesStub        func(context.Context, []v1.Node, []v1.Node, string, map[string]string) error
-	removeLabelsFromUnselectedNodesMutex       sync.RWMutex
-	removeLabelsFromUnselectedNodesArgsForCall []struct {
+	OrphanLabelsOnAllNodesStub        func(context.Context, string) ([]v1.Node, error)
+	orphanLabelsOnAllNodesMutex       sync.RWMutex
+	orphanLabelsOnAllNodesArgsForCall []struct {
 		arg1 context.Context
+		arg2 string
+	}
+	orphanLabelsOnAllNodesReturns struct {
+		result1 []v1.Node
+		result2 error
+	}
+	orphanLabelsOnAllNodesReturnsOnCall map[int]struct {
+		result1 []v1.Node
+		result2 error
+	}
+	PlanLabelChangesStub        func(*v1alpha1.NodeLabelPolicy, []v1.Node, []v1.Node, handlers.PolicyConflicts) (handlers.LabelPlan, error)
+	planLabelChangesMutex       sync.RWMutex
+	planLabelChangesArgsForCall []struct {
+		arg1 *v1alpha1.NodeLabelPolicy
 		arg2 []v1.Node
 		arg3 []v1.Node
-		arg4 string
-		arg5 map[string]string
+		arg4 handlers.PolicyConflicts
 	}
-	removeLabelsFromUnselectedNodesReturns struct {
-		result1 error
+	planLabelChangesReturns struct {
+		result1 handlers.LabelPlan
+		result2 error
 	}
-	removeLabelsFromUnselectedNodesReturnsOnCall map[int]struct {
-		result1 error
+	planLabelChangesReturnsOnCall map[int]struct {
+		result1 handlers.LabelPlan
+		result2 error
+	}
+	RemoveLabelsFromNodesStub        func(context.Context, []v1.Node, string, map[string]string) ([]v1.Node, error)
+	removeLabelsFromNodesMutex       sync.RWMutex
+	removeLabelsFromNodesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1.Node
+		arg3 string
+		arg4 map[string]string
+	}
+	removeLabelsFromNodesReturns struct {
+		result1 []v1.Node
+		result2 error
+	}
+	removeLabelsFromNodesReturnsOnCall map[int]struct {
+		result1 []v1.Node
+		result2 error
 	}
-	SelectNodesStub        func(context.Context, []v1.Node, v1alpha1.NodeLabelPolicyStrategy) ([]v1.Node, error)
+	SelectNodesStub        func(context.Context, []v1.Node, *v1alpha1.NodeLabelPolicy, []string) (handlers.NodeSelection, error)
 	selectNodesMutex       sync.RWMutex
 	selectNodesArgsForCall []struct {
 		arg1 context.Context
 		arg2 []v1.Node
-		arg3 v1alpha1.NodeLabelPolicyStrategy
+		arg3 *v1alpha1.NodeLabelPolicy
+		arg4 []string
 	}
 	selectNodesReturns struct {
-		result1 []v1.Node
+		result1 handlers.NodeSelection
 		result2 error
 	}
 	selectNodesReturnsOnCall map[int]struct {
-		result1 []v1.Node
+		result1 handlers.NodeSelection
 		result2 error
 	}
-	UpdatePolicyStatusStub        func(context.Context, *v1alpha1.NodeLabelPolicy, []string) error
+	UpdatePolicyStatusStub        func(context.Context, *v1alpha1.NodeLabelPolicy, handlers.ReconcileResult) error
 	updatePolicyStatusMutex       sync.RWMutex
 	updatePolicyStatusArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1alpha1.NodeLabelPolicy
-		arg3 []string
+		arg3 handlers.ReconcileResult
 	}
 	updatePolicyStatusReturns struct {
 		result1 error
@@ -85,26 +153,27 @@ type FakeNodeLabelPolicyHandler struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNode(arg1 context.Context, arg2 *v1.Node, arg3 map[string]string, arg4 string) error {
+func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNode(arg1 context.Context, arg2 *v1.Node, arg3 string, arg4 map[string]string, arg5 map[string]string) (handlers.ApplyResult, error) {
 	fake.applyLabelsToNodeMutex.Lock()
 	ret, specificReturn := fake.applyLabelsToNodeReturnsOnCall[len(fake.applyLabelsToNodeArgsForCall)]
 	fake.applyLabelsToNodeArgsForCall = append(fake.applyLabelsToNodeArgsForCall, struct {
 		arg1 context.Context
 		arg2 *v1.Node
-		arg3 map[string]string
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg3 string
+		arg4 map[string]string
+		arg5 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.ApplyLabelsToNodeStub
 	fakeReturns := fake.applyLabelsToNodeReturns
-	fake.recordInvocation("ApplyLabelsToNode", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ApplyLabelsToNode", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.applyLabelsToNodeMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeCallCount() int {
@@ -113,43 +182,118 @@ func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeCallCount() int {
 	return len(fake.applyLabelsToNodeArgsForCall)
 }
 
-func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeCalls(stub func(context.Context, *v1.Node, map[string]string, string) error) {
+func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeCalls(stub func(context.Context, *v1.Node, string, map[string]string, map[string]string) (handlers.ApplyResult, error)) {
 	fake.applyLabelsToNodeMutex.Lock()
 	defer fake.applyLabelsToNodeMutex.Unlock()
 	fake.ApplyLabelsToNodeStub = stub
 }
 
-func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeArgsForCall(i int) (context.Context, *v1.Node, map[string]string, string) {
+func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeArgsForCall(i int) (context.Context, *v1.Node, string, map[string]string, map[string]string) {
 	fake.applyLabelsToNodeMutex.RLock()
 	defer fake.applyLabelsToNodeMutex.RUnlock()
 	argsForCall := fake.applyLabelsToNodeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
-func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeReturns(result1 error) {
+func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeReturns(result1 handlers.ApplyResult, result2 error) {
 	fake.applyLabelsToNodeMutex.Lock()
 	defer fake.applyLabelsToNodeMutex.Unlock()
 	fake.ApplyLabelsToNodeStub = nil
 	fake.applyLabelsToNodeReturns = struct {
-		result1 error
-	}{result1}
+		result1 handlers.ApplyResult
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeReturnsOnCall(i int, result1 error) {
+func (fake *FakeNodeLabelPolicyHandler) ApplyLabelsToNodeReturnsOnCall(i int, result1 handlers.ApplyResult, result2 error) {
 	fake.applyLabelsToNodeMutex.Lock()
 	defer fake.applyLabelsToNodeMutex.Unlock()
 	fake.ApplyLabelsToNodeStub = nil
 	if fake.applyLabelsToNodeReturnsOnCall == nil {
 		fake.applyLabelsToNodeReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 handlers.ApplyResult
+			result2 error
 		})
 	}
 	fake.applyLabelsToNodeReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 handlers.ApplyResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) ApplyTaintsToNode(arg1 context.Context, arg2 *v1.Node, arg3 string, arg4 []v1.Taint) (bool, error) {
+	var arg4Copy []v1.Taint
+	if arg4 != nil {
+		arg4Copy = make([]v1.Taint, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.applyTaintsToNodeMutex.Lock()
+	ret, specificReturn := fake.applyTaintsToNodeReturnsOnCall[len(fake.applyTaintsToNodeArgsForCall)]
+	fake.applyTaintsToNodeArgsForCall = append(fake.applyTaintsToNodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.Node
+		arg3 string
+		arg4 []v1.Taint
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.ApplyTaintsToNodeStub
+	fakeReturns := fake.applyTaintsToNodeReturns
+	fake.recordInvocation("ApplyTaintsToNode", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.applyTaintsToNodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNodeLabelPolicyHandler) ApplyTaintsToNodeCallCount() int {
+	fake.applyTaintsToNodeMutex.RLock()
+	defer fake.applyTaintsToNodeMutex.RUnlock()
+	return len(fake.applyTaintsToNodeArgsForCall)
+}
+
+func (fake *FakeNodeLabelPolicyHandler) ApplyTaintsToNodeCalls(stub func(context.Context, *v1.Node, string, []v1.Taint) (bool, error)) {
+	fake.applyTaintsToNodeMutex.Lock()
+	defer fake.applyTaintsToNodeMutex.Unlock()
+	fake.ApplyTaintsToNodeStub = stub
+}
+
+func (fake *FakeNodeLabelPolicyHandler) ApplyTaintsToNodeArgsForCall(i int) (context.Context, *v1.Node, string, []v1.Taint) {
+	fake.applyTaintsToNodeMutex.RLock()
+	defer fake.applyTaintsToNodeMutex.RUnlock()
+	argsForCall := fake.applyTaintsToNodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeNodeLabelPolicyHandler) ApplyTaintsToNodeReturns(result1 bool, result2 error) {
+	fake.applyTaintsToNodeMutex.Lock()
+	defer fake.applyTaintsToNodeMutex.Unlock()
+	fake.ApplyTaintsToNodeStub = nil
+	fake.applyTaintsToNodeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodes(arg1 context.Context, arg2 string, arg3 map[string]string) error {
+func (fake *FakeNodeLabelPolicyHandler) ApplyTaintsToNodeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.applyTaintsToNodeMutex.Lock()
+	defer fake.applyTaintsToNodeMutex.Unlock()
+	fake.ApplyTaintsToNodeStub = nil
+	if fake.applyTaintsToNodeReturnsOnCall == nil {
+		fake.applyTaintsToNodeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.applyTaintsToNodeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodes(arg1 context.Context, arg2 string, arg3 map[string]string) ([]v1.Node, error) {
 	fake.cleanupLabelsFromAllNodesMutex.Lock()
 	ret, specificReturn := fake.cleanupLabelsFromAllNodesReturnsOnCall[len(fake.cleanupLabelsFromAllNodesArgsForCall)]
 	fake.cleanupLabelsFromAllNodesArgsForCall = append(fake.cleanupLabelsFromAllNodesArgsForCall, struct {
@@ -165,9 +309,9 @@ func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodes(arg1 context.C
 		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesCallCount() int {
@@ -176,7 +320,7 @@ func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesCallCount() int
 	return len(fake.cleanupLabelsFromAllNodesArgsForCall)
 }
 
-func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesCalls(stub func(context.Context, string, map[string]string) error) {
+func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesCalls(stub func(context.Context, string, map[string]string) ([]v1.Node, error)) {
 	fake.cleanupLabelsFromAllNodesMutex.Lock()
 	defer fake.cleanupLabelsFromAllNodesMutex.Unlock()
 	fake.CleanupLabelsFromAllNodesStub = stub
@@ -189,30 +333,169 @@ func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesArgsForCall(i i
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesReturns(result1 error) {
+func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesReturns(result1 []v1.Node, result2 error) {
 	fake.cleanupLabelsFromAllNodesMutex.Lock()
 	defer fake.cleanupLabelsFromAllNodesMutex.Unlock()
 	fake.CleanupLabelsFromAllNodesStub = nil
 	fake.cleanupLabelsFromAllNodesReturns = struct {
-		result1 error
-	}{result1}
+		result1 []v1.Node
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesReturnsOnCall(i int, result1 error) {
+func (fake *FakeNodeLabelPolicyHandler) CleanupLabelsFromAllNodesReturnsOnCall(i int, result1 []v1.Node, result2 error) {
 	fake.cleanupLabelsFromAllNodesMutex.Lock()
 	defer fake.cleanupLabelsFromAllNodesMutex.Unlock()
 	fake.CleanupLabelsFromAllNodesStub = nil
 	if fake.cleanupLabelsFromAllNodesReturnsOnCall == nil {
 		fake.cleanupLabelsFromAllNodesReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 []v1.Node
+			result2 error
 		})
 	}
 	fake.cleanupLabelsFromAllNodesReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 []v1.Node
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) DetectPolicyConflicts(arg1 context.Context, arg2 *v1alpha1.NodeLabelPolicy, arg3 []v1.Node) (handlers.PolicyConflicts, error) {
+	var arg3Copy []v1.Node
+	if arg3 != nil {
+		arg3Copy = make([]v1.Node, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.detectPolicyConflictsMutex.Lock()
+	ret, specificReturn := fake.detectPolicyConflictsReturnsOnCall[len(fake.detectPolicyConflictsArgsForCall)]
+	fake.detectPolicyConflictsArgsForCall = append(fake.detectPolicyConflictsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.NodeLabelPolicy
+		arg3 []v1.Node
+	}{arg1, arg2, arg3Copy})
+	stub := fake.DetectPolicyConflictsStub
+	fakeReturns := fake.detectPolicyConflictsReturns
+	fake.recordInvocation("DetectPolicyConflicts", []interface{}{arg1, arg2, arg3Copy})
+	fake.detectPolicyConflictsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNodeLabelPolicyHandler) DetectPolicyConflictsCallCount() int {
+	fake.detectPolicyConflictsMutex.RLock()
+	defer fake.detectPolicyConflictsMutex.RUnlock()
+	return len(fake.detectPolicyConflictsArgsForCall)
+}
+
+func (fake *FakeNodeLabelPolicyHandler) DetectPolicyConflictsCalls(stub func(context.Context, *v1alpha1.NodeLabelPolicy, []v1.Node) (handlers.PolicyConflicts, error)) {
+	fake.detectPolicyConflictsMutex.Lock()
+	defer fake.detectPolicyConflictsMutex.Unlock()
+	fake.DetectPolicyConflictsStub = stub
+}
+
+func (fake *FakeNodeLabelPolicyHandler) DetectPolicyConflictsArgsForCall(i int) (context.Context, *v1alpha1.NodeLabelPolicy, []v1.Node) {
+	fake.detectPolicyConflictsMutex.RLock()
+	defer fake.detectPolicyConflictsMutex.RUnlock()
+	argsForCall := fake.detectPolicyConflictsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNodeLabelPolicyHandler) DetectPolicyConflictsReturns(result1 handlers.PolicyConflicts, result2 error) {
+	fake.detectPolicyConflictsMutex.Lock()
+	defer fake.detectPolicyConflictsMutex.Unlock()
+	fake.DetectPolicyConflictsStub = nil
+	fake.detectPolicyConflictsReturns = struct {
+		result1 handlers.PolicyConflicts
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) DetectPolicyConflictsReturnsOnCall(i int, result1 handlers.PolicyConflicts, result2 error) {
+	fake.detectPolicyConflictsMutex.Lock()
+	defer fake.detectPolicyConflictsMutex.Unlock()
+	fake.DetectPolicyConflictsStub = nil
+	if fake.detectPolicyConflictsReturnsOnCall == nil {
+		fake.detectPolicyConflictsReturnsOnCall = make(map[int]struct {
+			result1 handlers.PolicyConflicts
+			result2 error
+		})
+	}
+	fake.detectPolicyConflictsReturnsOnCall[i] = struct {
+		result1 handlers.PolicyConflicts
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) OrphanLabelsOnAllNodes(arg1 context.Context, arg2 string) ([]v1.Node, error) {
+	fake.orphanLabelsOnAllNodesMutex.Lock()
+	ret, specificReturn := fake.orphanLabelsOnAllNodesReturnsOnCall[len(fake.orphanLabelsOnAllNodesArgsForCall)]
+	fake.orphanLabelsOnAllNodesArgsForCall = append(fake.orphanLabelsOnAllNodesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.OrphanLabelsOnAllNodesStub
+	fakeReturns := fake.orphanLabelsOnAllNodesReturns
+	fake.recordInvocation("OrphanLabelsOnAllNodes", []interface{}{arg1, arg2})
+	fake.orphanLabelsOnAllNodesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNodeLabelPolicyHandler) OrphanLabelsOnAllNodesCallCount() int {
+	fake.orphanLabelsOnAllNodesMutex.RLock()
+	defer fake.orphanLabelsOnAllNodesMutex.RUnlock()
+	return len(fake.orphanLabelsOnAllNodesArgsForCall)
+}
+
+func (fake *FakeNodeLabelPolicyHandler) OrphanLabelsOnAllNodesCalls(stub func(context.Context, string) ([]v1.Node, error)) {
+	fake.orphanLabelsOnAllNodesMutex.Lock()
+	defer fake.orphanLabelsOnAllNodesMutex.Unlock()
+	fake.OrphanLabelsOnAllNodesStub = stub
+}
+
+func (fake *FakeNodeLabelPolicyHandler) OrphanLabelsOnAllNodesArgsForCall(i int) (context.Context, string) {
+	fake.orphanLabelsOnAllNodesMutex.RLock()
+	defer fake.orphanLabelsOnAllNodesMutex.RUnlock()
+	argsForCall := fake.orphanLabelsOnAllNodesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNodeLabelPolicyHandler) OrphanLabelsOnAllNodesReturns(result1 []v1.Node, result2 error) {
+	fake.orphanLabelsOnAllNodesMutex.Lock()
+	defer fake.orphanLabelsOnAllNodesMutex.Unlock()
+	fake.OrphanLabelsOnAllNodesStub = nil
+	fake.orphanLabelsOnAllNodesReturns = struct {
+		result1 []v1.Node
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodes(arg1 context.Context, arg2 []v1.Node, arg3 []v1.Node, arg4 string, arg5 map[string]string) error {
+func (fake *FakeNodeLabelPolicyHandler) OrphanLabelsOnAllNodesReturnsOnCall(i int, result1 []v1.Node, result2 error) {
+	fake.orphanLabelsOnAllNodesMutex.Lock()
+	defer fake.orphanLabelsOnAllNodesMutex.Unlock()
+	fake.OrphanLabelsOnAllNodesStub = nil
+	if fake.orphanLabelsOnAllNodesReturnsOnCall == nil {
+		fake.orphanLabelsOnAllNodesReturnsOnCall = make(map[int]struct {
+			result1 []v1.Node
+			result2 error
+		})
+	}
+	fake.orphanLabelsOnAllNodesReturnsOnCall[i] = struct {
+		result1 []v1.Node
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) PlanLabelChanges(arg1 *v1alpha1.NodeLabelPolicy, arg2 []v1.Node, arg3 []v1.Node, arg4 handlers.PolicyConflicts) (handlers.LabelPlan, error) {
 	var arg2Copy []v1.Node
 	if arg2 != nil {
 		arg2Copy = make([]v1.Node, len(arg2))
@@ -223,89 +506,169 @@ func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodes(arg1 con
 		arg3Copy = make([]v1.Node, len(arg3))
 		copy(arg3Copy, arg3)
 	}
-	fake.removeLabelsFromUnselectedNodesMutex.Lock()
-	ret, specificReturn := fake.removeLabelsFromUnselectedNodesReturnsOnCall[len(fake.removeLabelsFromUnselectedNodesArgsForCall)]
-	fake.removeLabelsFromUnselectedNodesArgsForCall = append(fake.removeLabelsFromUnselectedNodesArgsForCall, struct {
-		arg1 context.Context
+	fake.planLabelChangesMutex.Lock()
+	ret, specificReturn := fake.planLabelChangesReturnsOnCall[len(fake.planLabelChangesArgsForCall)]
+	fake.planLabelChangesArgsForCall = append(fake.planLabelChangesArgsForCall, struct {
+		arg1 *v1alpha1.NodeLabelPolicy
 		arg2 []v1.Node
 		arg3 []v1.Node
-		arg4 string
-		arg5 map[string]string
-	}{arg1, arg2Copy, arg3Copy, arg4, arg5})
-	stub := fake.RemoveLabelsFromUnselectedNodesStub
-	fakeReturns := fake.removeLabelsFromUnselectedNodesReturns
-	fake.recordInvocation("RemoveLabelsFromUnselectedNodes", []interface{}{arg1, arg2Copy, arg3Copy, arg4, arg5})
-	fake.removeLabelsFromUnselectedNodesMutex.Unlock()
+		arg4 handlers.PolicyConflicts
+	}{arg1, arg2Copy, arg3Copy, arg4})
+	stub := fake.PlanLabelChangesStub
+	fakeReturns := fake.planLabelChangesReturns
+	fake.recordInvocation("PlanLabelChanges", []interface{}{arg1, arg2Copy, arg3Copy, arg4})
+	fake.planLabelChangesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodesCallCount() int {
-	fake.removeLabelsFromUnselectedNodesMutex.RLock()
-	defer fake.removeLabelsFromUnselectedNodesMutex.RUnlock()
-	return len(fake.removeLabelsFromUnselectedNodesArgsForCall)
+func (fake *FakeNodeLabelPolicyHandler) PlanLabelChangesCallCount() int {
+	fake.planLabelChangesMutex.RLock()
+	defer fake.planLabelChangesMutex.RUnlock()
+	return len(fake.planLabelChangesArgsForCall)
 }
 
-func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodesCalls(stub func(context.Context, []v1.Node, []v1.Node, string, map[string]string) error) {
-	fake.removeLabelsFromUnselectedNodesMutex.Lock()
-	defer fake.removeLabelsFromUnselectedNodesMutex.Unlock()
-	fake.RemoveLabelsFromUnselectedNodesStub = stub
+func (fake *FakeNodeLabelPolicyHandler) PlanLabelChangesCalls(stub func(*v1alpha1.NodeLabelPolicy, []v1.Node, []v1.Node, handlers.PolicyConflicts) (handlers.LabelPlan, error)) {
+	fake.planLabelChangesMutex.Lock()
+	defer fake.planLabelChangesMutex.Unlock()
+	fake.PlanLabelChangesStub = stub
 }
 
-func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodesArgsForCall(i int) (context.Context, []v1.Node, []v1.Node, string, map[string]string) {
-	fake.removeLabelsFromUnselectedNodesMutex.RLock()
-	defer fake.removeLabelsFromUnselectedNodesMutex.RUnlock()
-	argsForCall := fake.removeLabelsFromUnselectedNodesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+func (fake *FakeNodeLabelPolicyHandler) PlanLabelChangesArgsForCall(i int) (*v1alpha1.NodeLabelPolicy, []v1.Node, []v1.Node, handlers.PolicyConflicts) {
+	fake.planLabelChangesMutex.RLock()
+	defer fake.planLabelChangesMutex.RUnlock()
+	argsForCall := fake.planLabelChangesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodesReturns(result1 error) {
-	fake.removeLabelsFromUnselectedNodesMutex.Lock()
-	defer fake.removeLabelsFromUnselectedNodesMutex.Unlock()
-	fake.RemoveLabelsFromUnselectedNodesStub = nil
-	fake.removeLabelsFromUnselectedNodesReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeNodeLabelPolicyHandler) PlanLabelChangesReturns(result1 handlers.LabelPlan, result2 error) {
+	fake.planLabelChangesMutex.Lock()
+	defer fake.planLabelChangesMutex.Unlock()
+	fake.PlanLabelChangesStub = nil
+	fake.planLabelChangesReturns = struct {
+		result1 handlers.LabelPlan
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodesReturnsOnCall(i int, result1 error) {
-	fake.removeLabelsFromUnselectedNodesMutex.Lock()
-	defer fake.removeLabelsFromUnselectedNodesMutex.Unlock()
-	fake.RemoveLabelsFromUnselectedNodesStub = nil
-	if fake.removeLabelsFromUnselectedNodesReturnsOnCall == nil {
-		fake.removeLabelsFromUnselectedNodesReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeNodeLabelPolicyHandler) PlanLabelChangesReturnsOnCall(i int, result1 handlers.LabelPlan, result2 error) {
+	fake.planLabelChangesMutex.Lock()
+	defer fake.planLabelChangesMutex.Unlock()
+	fake.PlanLabelChangesStub = nil
+	if fake.planLabelChangesReturnsOnCall == nil {
+		fake.planLabelChangesReturnsOnCall = make(map[int]struct {
+			result1 handlers.LabelPlan
+			result2 error
 		})
 	}
-	fake.removeLabelsFromUnselectedNodesReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.planLabelChangesReturnsOnCall[i] = struct {
+		result1 handlers.LabelPlan
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) SelectNodes(arg1 context.Context, arg2 []v1.Node, arg3 v1alpha1.NodeLabelPolicyStrategy) ([]v1.Node, error) {
+func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromNodes(arg1 context.Context, arg2 []v1.Node, arg3 string, arg4 map[string]string) ([]v1.Node, error) {
 	var arg2Copy []v1.Node
 	if arg2 != nil {
 		arg2Copy = make([]v1.Node, len(arg2))
 		copy(arg2Copy, arg2)
 	}
+	fake.removeLabelsFromNodesMutex.Lock()
+	ret, specificReturn := fake.removeLabelsFromNodesReturnsOnCall[len(fake.removeLabelsFromNodesArgsForCall)]
+	fake.removeLabelsFromNodesArgsForCall = append(fake.removeLabelsFromNodesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1.Node
+		arg3 string
+		arg4 map[string]string
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.RemoveLabelsFromNodesStub
+	fakeReturns := fake.removeLabelsFromNodesReturns
+	fake.recordInvocation("RemoveLabelsFromNodes", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.removeLabelsFromNodesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromNodesCallCount() int {
+	fake.removeLabelsFromNodesMutex.RLock()
+	defer fake.removeLabelsFromNodesMutex.RUnlock()
+	return len(fake.removeLabelsFromNodesArgsForCall)
+}
+
+func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromNodesCalls(stub func(context.Context, []v1.Node, string, map[string]string) ([]v1.Node, error)) {
+	fake.removeLabelsFromNodesMutex.Lock()
+	defer fake.removeLabelsFromNodesMutex.Unlock()
+	fake.RemoveLabelsFromNodesStub = stub
+}
+
+func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromNodesArgsForCall(i int) (context.Context, []v1.Node, string, map[string]string) {
+	fake.removeLabelsFromNodesMutex.RLock()
+	defer fake.removeLabelsFromNodesMutex.RUnlock()
+	argsForCall := fake.removeLabelsFromNodesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromNodesReturns(result1 []v1.Node, result2 error) {
+	fake.removeLabelsFromNodesMutex.Lock()
+	defer fake.removeLabelsFromNodesMutex.Unlock()
+	fake.RemoveLabelsFromNodesStub = nil
+	fake.removeLabelsFromNodesReturns = struct {
+		result1 []v1.Node
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) RemoveLabelsFromNodesReturnsOnCall(i int, result1 []v1.Node, result2 error) {
+	fake.removeLabelsFromNodesMutex.Lock()
+	defer fake.removeLabelsFromNodesMutex.Unlock()
+	fake.RemoveLabelsFromNodesStub = nil
+	if fake.removeLabelsFromNodesReturnsOnCall == nil {
+		fake.removeLabelsFromNodesReturnsOnCall = make(map[int]struct {
+			result1 []v1.Node
+			result2 error
+		})
+	}
+	fake.removeLabelsFromNodesReturnsOnCall[i] = struct {
+		result1 []v1.Node
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNodeLabelPolicyHandler) SelectNodes(arg1 context.Context, arg2 []v1.Node, arg3 *v1alpha1.NodeLabelPolicy, arg4 []string) (handlers.NodeSelection, error) {
+	var arg2Copy []v1.Node
+	if arg2 != nil {
+		arg2Copy = make([]v1.Node, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
 	fake.selectNodesMutex.Lock()
 	ret, specificReturn := fake.selectNodesReturnsOnCall[len(fake.selectNodesArgsForCall)]
 	fake.selectNodesArgsForCall = append(fake.selectNodesArgsForCall, struct {
 		arg1 context.Context
 		arg2 []v1.Node
-		arg3 v1alpha1.NodeLabelPolicyStrategy
-	}{arg1, arg2Copy, arg3})
+		arg3 *v1alpha1.NodeLabelPolicy
+		arg4 []string
+	}{arg1, arg2Copy, arg3, arg4Copy})
 	stub := fake.SelectNodesStub
 	fakeReturns := fake.selectNodesReturns
-	fake.recordInvocation("SelectNodes", []interface{}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("SelectNodes", []interface{}{arg1, arg2Copy, arg3, arg4Copy})
 	fake.selectNodesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -319,61 +682,56 @@ func (fake *FakeNodeLabelPolicyHandler) SelectNodesCallCount() int {
 	return len(fake.selectNodesArgsForCall)
 }
 
-func (fake *FakeNodeLabelPolicyHandler) SelectNodesCalls(stub func(context.Context, []v1.Node, v1alpha1.NodeLabelPolicyStrategy) ([]v1.Node, error)) {
+func (fake *FakeNodeLabelPolicyHandler) SelectNodesCalls(stub func(context.Context, []v1.Node, *v1alpha1.NodeLabelPolicy, []string) (handlers.NodeSelection, error)) {
 	fake.selectNodesMutex.Lock()
 	defer fake.selectNodesMutex.Unlock()
 	fake.SelectNodesStub = stub
 }
 
-func (fake *FakeNodeLabelPolicyHandler) SelectNodesArgsForCall(i int) (context.Context, []v1.Node, v1alpha1.NodeLabelPolicyStrategy) {
+func (fake *FakeNodeLabelPolicyHandler) SelectNodesArgsForCall(i int) (context.Context, []v1.Node, *v1alpha1.NodeLabelPolicy, []string) {
 	fake.selectNodesMutex.RLock()
 	defer fake.selectNodesMutex.RUnlock()
 	argsForCall := fake.selectNodesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *FakeNodeLabelPolicyHandler) SelectNodesReturns(result1 []v1.Node, result2 error) {
+func (fake *FakeNodeLabelPolicyHandler) SelectNodesReturns(result1 handlers.NodeSelection, result2 error) {
 	fake.selectNodesMutex.Lock()
 	defer fake.selectNodesMutex.Unlock()
 	fake.SelectNodesStub = nil
 	fake.selectNodesReturns = struct {
-		result1 []v1.Node
+		result1 handlers.NodeSelection
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) SelectNodesReturnsOnCall(i int, result1 []v1.Node, result2 error) {
+func (fake *FakeNodeLabelPolicyHandler) SelectNodesReturnsOnCall(i int, result1 handlers.NodeSelection, result2 error) {
 	fake.selectNodesMutex.Lock()
 	defer fake.selectNodesMutex.Unlock()
 	fake.SelectNodesStub = nil
 	if fake.selectNodesReturnsOnCall == nil {
 		fake.selectNodesReturnsOnCall = make(map[int]struct {
-			result1 []v1.Node
+			result1 handlers.NodeSelection
 			result2 error
 		})
 	}
 	fake.selectNodesReturnsOnCall[i] = struct {
-		result1 []v1.Node
+		result1 handlers.NodeSelection
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatus(arg1 context.Context, arg2 *v1alpha1.NodeLabelPolicy, arg3 []string) error {
-	var arg3Copy []string
-	if arg3 != nil {
-		arg3Copy = make([]string, len(arg3))
-		copy(arg3Copy, arg3)
-	}
+func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatus(arg1 context.Context, arg2 *v1alpha1.NodeLabelPolicy, arg3 handlers.ReconcileResult) error {
 	fake.updatePolicyStatusMutex.Lock()
 	ret, specificReturn := fake.updatePolicyStatusReturnsOnCall[len(fake.updatePolicyStatusArgsForCall)]
 	fake.updatePolicyStatusArgsForCall = append(fake.updatePolicyStatusArgsForCall, struct {
 		arg1 context.Context
 		arg2 *v1alpha1.NodeLabelPolicy
-		arg3 []string
-	}{arg1, arg2, arg3Copy})
+		arg3 handlers.ReconcileResult
+	}{arg1, arg2, arg3})
 	stub := fake.UpdatePolicyStatusStub
 	fakeReturns := fake.updatePolicyStatusReturns
-	fake.recordInvocation("UpdatePolicyStatus", []interface{}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("UpdatePolicyStatus", []interface{}{arg1, arg2, arg3})
 	fake.updatePolicyStatusMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3)
@@ -390,13 +748,13 @@ func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatusCallCount() int {
 	return len(fake.updatePolicyStatusArgsForCall)
 }
 
-func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatusCalls(stub func(context.Context, *v1alpha1.NodeLabelPolicy, []string) error) {
+func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatusCalls(stub func(context.Context, *v1alpha1.NodeLabelPolicy, handlers.ReconcileResult) error) {
 	fake.updatePolicyStatusMutex.Lock()
 	defer fake.updatePolicyStatusMutex.Unlock()
 	fake.UpdatePolicyStatusStub = stub
 }
 
-func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatusArgsForCall(i int) (context.Context, *v1alpha1.NodeLabelPolicy, []string) {
+func (fake *FakeNodeLabelPolicyHandler) UpdatePolicyStatusArgsForCall(i int) (context.Context, *v1alpha1.NodeLabelPolicy, handlers.ReconcileResult) {
 	fake.updatePolicyStatusMutex.RLock()
 	defer fake.updatePolicyStatusMutex.RUnlock()
 	argsForCall := fake.updatePolicyStatusArgsForCall[i]
@@ -431,10 +789,18 @@ func (fake *FakeNodeLabelPolicyHandler) Invocations() map[string][][]interface{}
 	defer fake.invocationsMutex.RUnlock()
 	fake.applyLabelsToNodeMutex.RLock()
 	defer fake.applyLabelsToNodeMutex.RUnlock()
+	fake.applyTaintsToNodeMutex.RLock()
+	defer fake.applyTaintsToNodeMutex.RUnlock()
 	fake.cleanupLabelsFromAllNodesMutex.RLock()
 	defer fake.cleanupLabelsFromAllNodesMutex.RUnlock()
-	fake.removeLabelsFromUnselectedNodesMutex.RLock()
-	defer fake.removeLabelsFromUnselectedNodesMutex.RUnlock()
+	fake.detectPolicyConflictsMutex.RLock()
+	defer fake.detectPolicyConflictsMutex.RUnlock()
+	fake.orphanLabelsOnAllNodesMutex.RLock()
+	defer fake.orphanLabelsOnAllNodesMutex.RUnlock()
+	fake.planLabelChangesMutex.RLock()
+	defer fake.planLabelChangesMutex.RUnlock()
+	fake.removeLabelsFromNodesMutex.RLock()
+	defer fake.removeLabelsFromNodesMutex.RUnlock()
 	fake.selectNodesMutex.RLock()
 	defer fake.selectNodesMutex.RUnlock()
 	fake.updatePolicyStatusMutex.RLock()
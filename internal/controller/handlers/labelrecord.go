@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jivvon/node-label-controller/internal/constants"
+)
+
+// LabelsAnnotationKey returns the node annotation recording the label keys a policy applied to the node
+// and the values they had before the policy took them over
+func LabelsAnnotationKey(policyName string) string {
+	return fmt.Sprintf("%s.%s/labels", constants.ManagedByLabelPrefix, policyName)
+}
+
+// labelRecord maps the label keys a policy applied to a node to their prior value, or nil if the key was not set
+type labelRecord map[string]*string
+
+// recordedLabels returns the label record of the policy on the node
+// An unreadable record is treated as empty, so that no value is restored without proof of what it was
+func recordedLabels(node *corev1.Node, policyName string) labelRecord {
+	value, ok := node.Annotations[LabelsAnnotationKey(policyName)]
+	if !ok {
+		return nil
+	}
+
+	var record labelRecord
+	if err := json.Unmarshal([]byte(value), &record); err != nil {
+		return nil
+	}
+	return record
+}
+
+// planLabelRecord computes the record of the labels the policy applies to the node and the prior values to
+// restore for recorded keys it no longer applies. A key is recorded with the value the node carries when the
+// policy first applies it, unless the policy already owns the key, and a prior value is only restored while
+// the policy still owns the key, so that a value someone else set in the meantime is kept.
+func planLabelRecord(node *corev1.Node, policyName string, labels map[string]string, ownedLabels map[string]bool) (labelRecord, map[string]string) {
+	previous := recordedLabels(node, policyName)
+
+	record := make(labelRecord, len(labels))
+	for key := range labels {
+		if prior, ok := previous[key]; ok {
+			record[key] = prior
+			continue
+		}
+		if current, ok := node.Labels[key]; ok && !ownedLabels[key] {
+			record[key] = &current
+			continue
+		}
+		record[key] = nil
+	}
+
+	restore := make(map[string]string)
+	for key, prior := range previous {
+		if _, applied := record[key]; applied || prior == nil || !ownedLabels[key] {
+			continue
+		}
+		if current, ok := node.Labels[key]; !ok || current != *prior {
+			restore[key] = *prior
+		}
+	}
+
+	return record, restore
+}
+
+// String returns the annotation value of the record
+func (r labelRecord) String() string {
+	value, _ := json.Marshal(r)
+	return string(value)
+}
+
+// restoreLabelPatch builds the JSON patch setting a label back to its prior value
+// The patch first tests that the node still carries the value the restore was planned from, so that it fails
+// instead of overwriting a value someone else set in the meantime.
+func restoreLabelPatch(node *corev1.Node, key, prior string) ([]byte, error) {
+	path := "/metadata/labels/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+
+	ops := []map[string]string{{"op": "add", "path": path, "value": prior}}
+	if current, ok := node.Labels[key]; ok {
+		ops = []map[string]string{
+			{"op": "test", "path": path, "value": current},
+			{"op": "replace", "path": path, "value": prior},
+		}
+	}
+	return json.Marshal(ops)
+}
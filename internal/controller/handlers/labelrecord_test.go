@@ -0,0 +1,240 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+var _ = Describe("Label record", func() {
+	const annotationKey = "nlp.test/labels"
+
+	var node *corev1.Node
+
+	BeforeEach(func() {
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-node",
+				ResourceVersion: "7",
+				Labels:          map[string]string{"team": "infra"},
+			},
+		}
+	})
+
+	Describe("planLabelRecord", func() {
+		It("should record the value a key had before the policy took it over", func() {
+			record, restore := planLabelRecord(node, "test", map[string]string{"team": "devops", "workload": "monitoring"}, nil)
+
+			Expect(record).To(Equal(labelRecord{"team": ptr.To("infra"), "workload": nil}))
+			Expect(record.String()).To(Equal(`{"team":"infra","workload":null}`))
+			Expect(restore).To(BeEmpty())
+		})
+
+		It("should keep the recorded value once the policy applied the key", func() {
+			node.Labels["team"] = "devops"
+			node.Annotations = map[string]string{annotationKey: `{"team":"infra"}`}
+
+			record, _ := planLabelRecord(node, "test", map[string]string{"team": "devops"}, map[string]bool{"team": true})
+			Expect(record).To(Equal(labelRecord{"team": ptr.To("infra")}))
+		})
+
+		It("should not record a value for keys the policy already owns", func() {
+			node.Labels["team"] = "devops"
+
+			record, _ := planLabelRecord(node, "test", map[string]string{"team": "devops"}, map[string]bool{"team": true})
+			Expect(record).To(Equal(labelRecord{"team": nil}))
+		})
+
+		It("should restore the prior value of a key the policy no longer applies", func() {
+			node.Labels["team"] = "devops"
+			node.Annotations = map[string]string{annotationKey: `{"team":"infra","workload":null}`}
+
+			record, restore := planLabelRecord(node, "test", map[string]string{"workload": "monitoring"},
+				map[string]bool{"team": true, "workload": true})
+			Expect(record).To(Equal(labelRecord{"workload": nil}))
+			Expect(restore).To(Equal(map[string]string{"team": "infra"}))
+		})
+
+		It("should not restore a key someone else set since", func() {
+			node.Labels["team"] = "platform"
+			node.Annotations = map[string]string{annotationKey: `{"team":"infra"}`}
+
+			_, restore := planLabelRecord(node, "test", nil, nil)
+			Expect(restore).To(BeEmpty())
+		})
+
+		It("should treat an unreadable record as empty", func() {
+			node.Annotations = map[string]string{annotationKey: "not json"}
+
+			Expect(recordedLabels(node, "test")).To(BeNil())
+		})
+	})
+
+	Describe("restoreLabelPatch", func() {
+		It("should escape the label key in the patch path", func() {
+			node.Labels["example.com/team"] = "devops"
+
+			data, err := restoreLabelPatch(node, "example.com/team", "infra")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`[{"op":"test","path":"/metadata/labels/example.com~1team","value":"devops"},` +
+				`{"op":"replace","path":"/metadata/labels/example.com~1team","value":"infra"}]`))
+		})
+
+		It("should add a label the node no longer carries", func() {
+			data, err := restoreLabelPatch(node, "workload", "monitoring")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`[{"op":"add","path":"/metadata/labels/workload","value":"monitoring"}]`))
+		})
+	})
+
+	Describe("ApplyLabelsToNode", func() {
+		It("should restore the prior value before applying the labels without the key", func() {
+			node.Labels["team"] = "devops"
+			node.Labels[ManagedByLabelKey("test")] = managedByLabelValue
+			node.Annotations = map[string]string{annotationKey: `{"team":"infra"}`}
+			node.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "nlp/test",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(
+						`{"f:metadata":{"f:annotations":{"f:nlp.test/labels":{}},"f:labels":{"f:team":{},"f:nlp.test/managed-by":{}}}}`)},
+				},
+			}
+			fakeClient := &k8sfakes.FakeClient{}
+			var restored string
+			fakeClient.PatchStub = func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+				if patch.Type() == types.JSONPatchType {
+					data, err := patch.Data(obj)
+					restored = string(data)
+					return err
+				}
+				return nil
+			}
+
+			result, err := NewNodeLabelPolicyHandler(fakeClient).ApplyLabelsToNode(context.Background(), node, "test",
+				map[string]string{"workload": "monitoring"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Changed).To(BeTrue())
+			Expect(node.Labels).To(HaveKeyWithValue("team", "infra"))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(2))
+			Expect(restored).To(MatchJSON(`[{"op":"test","path":"/metadata/labels/team","value":"devops"},{"op":"replace","path":"/metadata/labels/team","value":"infra"}]`))
+
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(1)
+			Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+			Expect(obj.GetLabels()).NotTo(HaveKey("team"))
+			Expect(obj.GetAnnotations()).To(HaveKeyWithValue(annotationKey, `{"workload":null}`))
+		})
+	})
+
+	Describe("RemoveLabelsFromNodes", func() {
+		It("should restore the prior values of the labels the policy took over", func() {
+			node.Labels["team"] = "devops"
+			node.Labels["workload"] = "monitoring"
+			node.Labels[ManagedByLabelKey("test")] = managedByLabelValue
+			node.Annotations = map[string]string{annotationKey: `{"team":"infra","workload":null}`}
+			node.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "nlp/test",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(
+						`{"f:metadata":{"f:annotations":{"f:nlp.test/labels":{}},"f:labels":{"f:team":{},"f:workload":{},"f:nlp.test/managed-by":{}}}}`)},
+				},
+			}
+			fakeClient := &k8sfakes.FakeClient{}
+			var restored string
+			fakeClient.PatchStub = func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+				if patch.Type() == types.JSONPatchType {
+					data, err := patch.Data(obj)
+					restored = string(data)
+					return err
+				}
+				return nil
+			}
+
+			released, err := NewNodeLabelPolicyHandler(fakeClient).RemoveLabelsFromNodes(context.Background(), []corev1.Node{*node}, "test", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(HaveLen(1))
+			Expect(released[0].Labels).To(HaveKeyWithValue("team", "infra"))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(2))
+			Expect(restored).To(MatchJSON(`[{"op":"test","path":"/metadata/labels/team","value":"devops"},{"op":"replace","path":"/metadata/labels/team","value":"infra"}]`))
+
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(1)
+			Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+			Expect(obj.GetLabels()).To(BeEmpty())
+			Expect(obj.GetAnnotations()).To(BeEmpty())
+		})
+
+		It("should keep a value someone else set since the restore was planned", func() {
+			node.Labels["team"] = "devops"
+			node.Labels[ManagedByLabelKey("test")] = managedByLabelValue
+			node.Annotations = map[string]string{annotationKey: `{"team":"infra"}`}
+			node.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "nlp/test",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(
+						`{"f:metadata":{"f:annotations":{"f:nlp.test/labels":{}},"f:labels":{"f:team":{},"f:nlp.test/managed-by":{}}}}`)},
+				},
+			}
+			fakeClient := &k8sfakes.FakeClient{}
+			fakeClient.PatchStub = func(_ context.Context, _ client.Object, patch client.Patch, _ ...client.PatchOption) error {
+				if patch.Type() == types.JSONPatchType {
+					return apierrors.NewGenericServerResponse(http.StatusUnprocessableEntity, "", schema.GroupResource{}, "",
+						"testing value /metadata/labels/team failed", 0, false)
+				}
+				return nil
+			}
+
+			released, err := NewNodeLabelPolicyHandler(fakeClient).RemoveLabelsFromNodes(context.Background(), []corev1.Node{*node}, "test", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(HaveLen(1))
+			Expect(released[0].Labels).To(HaveKeyWithValue("team", "devops"))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(2))
+			_, _, patch, _ := fakeClient.PatchArgsForCall(1)
+			Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+		})
+
+		It("should only apply the empty configuration when no label was taken over", func() {
+			node.Labels = map[string]string{ManagedByLabelKey("test"): managedByLabelValue, "workload": "monitoring"}
+			node.Annotations = map[string]string{annotationKey: `{"workload":null}`}
+			fakeClient := &k8sfakes.FakeClient{}
+
+			_, err := NewNodeLabelPolicyHandler(fakeClient).RemoveLabelsFromNodes(context.Background(), []corev1.Node{*node}, "test", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, _, patch, _ := fakeClient.PatchArgsForCall(0)
+			Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+		})
+	})
+})
@@ -2,13 +2,22 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sort"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
 	"github.com/jivvon/node-label-controller/internal/constants"
@@ -27,25 +36,189 @@ const (
 //counterfeiter:generate . NodeLabelPolicyHandler
 type NodeLabelPolicyHandler interface {
 	// SelectNodes selects nodes based on the given strategy
-	SelectNodes(ctx context.Context, nodes []corev1.Node, strategy nlpv1alpha1.NodeLabelPolicyStrategy) ([]corev1.Node, error)
+	// currentNodeNames are the nodes currently holding the policy's labels, preferred by sticky strategies
+	SelectNodes(ctx context.Context, nodes []corev1.Node, policy *nlpv1alpha1.NodeLabelPolicy, currentNodeNames []string) (NodeSelection, error)
 
-	// ApplyLabelsToNode applies labels to a specific node
-	ApplyLabelsToNode(ctx context.Context, node *corev1.Node, labels map[string]string, managedByLabelKey string) error
+	// ApplyLabelsToNode applies labels and annotations to a specific node
+	// It reports whether the node changed and the label fields that were taken over from other field managers
+	ApplyLabelsToNode(ctx context.Context, node *corev1.Node, policyName string, labels map[string]string, annotations map[string]string) (ApplyResult, error)
 
-	// RemoveLabelsFromUnselectedNodes removes labels from nodes that are not selected
-	RemoveLabelsFromUnselectedNodes(ctx context.Context, allNodes []corev1.Node, selectedNodes []corev1.Node, managedByLabelKey string, policyLabels map[string]string) error
+	// ApplyTaintsToNode adds the policy's taints to a specific node and removes the taints it no longer sets
+	// It reports whether the node changed
+	ApplyTaintsToNode(ctx context.Context, node *corev1.Node, policyName string, taints []corev1.Taint) (bool, error)
 
-	// CleanupLabelsFromAllNodes removes all labels related to a policy from all nodes
-	CleanupLabelsFromAllNodes(ctx context.Context, policyName string, policyLabels map[string]string) error
+	// DetectPolicyConflicts returns the label keys the policy leaves to higher-priority policies on the given nodes
+	DetectPolicyConflicts(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, nodes []corev1.Node) (PolicyConflicts, error)
+
+	// PlanLabelChanges computes the label and taint changes that enforce the selection without changing any node
+	// allNodes must include nodes that no longer match the policy's selector so they get cleaned up
+	// It returns an error if a templated label value can not be rendered for a selected node
+	PlanLabelChanges(policy *nlpv1alpha1.NodeLabelPolicy, allNodes []corev1.Node, selectedNodes []corev1.Node, conflicts PolicyConflicts) (LabelPlan, error)
+
+	// RemoveLabelsFromNodes removes the policy's labels and taints from the given nodes and returns the released nodes
+	RemoveLabelsFromNodes(ctx context.Context, nodes []corev1.Node, policyName string, policyLabels map[string]string) ([]corev1.Node, error)
+
+	// CleanupLabelsFromAllNodes removes all labels and taints related to a policy from all nodes and returns the released nodes
+	CleanupLabelsFromAllNodes(ctx context.Context, policyName string, policyLabels map[string]string) ([]corev1.Node, error)
+
+	// OrphanLabelsOnAllNodes stops tracking a policy's labels and taints on all nodes while leaving them in place
+	// and returns the orphaned nodes
+	OrphanLabelsOnAllNodes(ctx context.Context, policyName string) ([]corev1.Node, error)
 
 	// UpdatePolicyStatus updates the status of a NodeLabelPolicy
-	UpdatePolicyStatus(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, selectedNodeNames []string) error
+	UpdatePolicyStatus(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, result ReconcileResult) error
+}
+
+// ReconcileResult describes the outcome of a reconciliation to be reported in the policy status
+type ReconcileResult struct {
+	// Selection is the node selection the reconciliation enforced
+	Selection NodeSelection
+
+	// ConflictingNodes are selected nodes on which another field manager owned one of the policy's labels
+	ConflictingNodes []string
+
+	// PolicyConflicts are the label keys left to higher-priority policies
+	PolicyConflicts PolicyConflicts
+
+	// Plan are the label changes computed for the selection, reported instead of executed for dry-run policies
+	Plan LabelPlan
+
+	// Err is the error that interrupted the reconciliation, if any
+	Err error
+}
+
+// ApplyResult describes the outcome of applying a policy's labels to a node
+type ApplyResult struct {
+	// Changed reports whether the node was patched
+	Changed bool
+
+	// Labeled reports whether the node gained the policy's labels, not having carried them before the patch
+	Labeled bool
+
+	// Conflicts are the label fields that were taken over from field managers other than policies
+	Conflicts []string
+}
+
+// LabelPlan describes the node changes needed to enforce a policy's selection
+type LabelPlan struct {
+	// Apply are the selected nodes whose labels, annotations or taints are missing or differ, with what to apply to each
+	Apply []PlannedLabels
+
+	// Remove are the nodes that carry the policy's labels but are not selected
+	Remove []corev1.Node
+
+	// PendingLabel are the selected nodes the rollout limits hold back from being labeled until a later reconciliation
+	PendingLabel []string
+
+	// PendingUnlabel are the deselected nodes the rollout limits hold back from being unlabeled until a later reconciliation
+	PendingUnlabel []string
+}
+
+// PlannedLabels are the labels planned for a node
+type PlannedLabels struct {
+	// Node is the node to label
+	Node corev1.Node
+
+	// Labels are the policy's labels without the keys left to higher-priority policies
+	Labels map[string]string
+
+	// Annotations are the policy's annotations, along with the time the node was labeled if the policy
+	// has a minimum selection duration
+	Annotations map[string]string
+
+	// Taints are the policy's taints
+	Taints []corev1.Taint
+}
+
+// NodesToLabel returns the names of the nodes the plan applies labels to
+func (p LabelPlan) NodesToLabel() []string {
+	names := make([]string, len(p.Apply))
+	for i, planned := range p.Apply {
+		names[i] = planned.Node.Name
+	}
+	return names
+}
+
+// NodesToUnlabel returns the names of the nodes the plan removes labels from
+func (p LabelPlan) NodesToUnlabel() []string {
+	names := make([]string, len(p.Remove))
+	for i, node := range p.Remove {
+		names[i] = node.Name
+	}
+	return names
+}
+
+// RollingOut reports whether the rollout limits held back label moves for a later reconciliation
+func (p LabelPlan) RollingOut() bool {
+	return len(p.PendingLabel) > 0 || len(p.PendingUnlabel) > 0
+}
+
+// NodeUpdateError is returned when the labels of a node could not be changed
+type NodeUpdateError struct {
+	// Node is the node that could not be changed
+	Node *corev1.Node
+
+	// Action describes the attempted change, e.g. "update"
+	Action string
+
+	// Err is the error returned by the API server
+	Err error
+}
+
+func (e *NodeUpdateError) Error() string {
+	return fmt.Sprintf("failed to %s node %s: %v", e.Action, e.Node.Name, e.Err)
+}
+
+func (e *NodeUpdateError) Unwrap() error {
+	return e.Err
+}
+
+// NodeSelection is the result of selecting nodes for a policy
+type NodeSelection struct {
+	// Nodes are the selected nodes in strategy order
+	Nodes []corev1.Node
+
+	// DesiredCount is the number of nodes the strategy resolved to
+	DesiredCount int32
+
+	// EligibleCount is the number of nodes the strategy could choose from
+	EligibleCount int32
+
+	// Exclusions counts the nodes each eligibility filter excluded
+	Exclusions nlpv1alpha1.NodeExclusions
+
+	// ToleratedUntil is the earliest time a currently selected NotReady node stops being tolerated,
+	// or nil if no NotReady node is tolerated
+	ToleratedUntil *time.Time
+
+	// HeldUntil is the earliest time a node kept selected by the minimum selection duration may be deselected,
+	// or nil if no node is kept
+	HeldUntil *time.Time
+}
+
+// NodeNames returns the names of the selected nodes
+func (s NodeSelection) NodeNames() []string {
+	names := make([]string, len(s.Nodes))
+	for i, node := range s.Nodes {
+		names[i] = node.Name
+	}
+	return names
 }
 
 type nodeLabelPolicyHandler struct {
 	client k8s.Client
 }
 
+// ManagedByLabelKey returns the label marking nodes that carry a policy's labels
+func ManagedByLabelKey(policyName string) string {
+	return fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, policyName)
+}
+
+// FieldManager returns the server-side apply field manager owning a policy's labels
+func FieldManager(policyName string) string {
+	return fmt.Sprintf("%s/%s", constants.ManagedByLabelPrefix, policyName)
+}
+
 // NewNodeLabelPolicyHandler creates a new NodeLabelPolicyHandler
 func NewNodeLabelPolicyHandler(client k8s.Client) NodeLabelPolicyHandler {
 	return &nodeLabelPolicyHandler{
@@ -53,138 +226,825 @@ func NewNodeLabelPolicyHandler(client k8s.Client) NodeLabelPolicyHandler {
 	}
 }
 
-// SelectNodes selects nodes based on the given strategy
-func (h *nodeLabelPolicyHandler) SelectNodes(ctx context.Context, nodes []corev1.Node, strategy nlpv1alpha1.NodeLabelPolicyStrategy) ([]corev1.Node, error) {
-	if len(nodes) == 0 {
-		return []corev1.Node{}, nil
+// SelectNodes selects nodes based on the policy's strategy
+// Only Ready nodes passing the eligibility filters are considered, and currently selected nodes that have
+// been NotReady for less than the policy's NotReady toleration still count as Ready
+func (h *nodeLabelPolicyHandler) SelectNodes(ctx context.Context, nodes []corev1.Node, policy *nlpv1alpha1.NodeLabelPolicy, currentNodeNames []string) (NodeSelection, error) {
+	strategy := policy.Spec.Strategy
+	now := time.Now()
+
+	tolerated, toleratedUntil := toleratedNotReadyNodes(nodes, currentNodeNames, policy.Spec.NotReadyToleration, now)
+	eligibleNodes, exclusions := utils.FilterEligibleNodes(nodes, policy.Spec.Eligibility, tolerated, now)
+
+	desiredCount, err := resolveCount(strategy, len(eligibleNodes))
+	if err != nil {
+		return NodeSelection{}, err
+	}
+
+	preferred, err := preferredNodes(strategy, currentNodeNames)
+	if err != nil {
+		return NodeSelection{}, err
+	}
+
+	selection := NodeSelection{
+		Nodes:         []corev1.Node{},
+		DesiredCount:  int32(desiredCount),
+		EligibleCount: int32(len(eligibleNodes)),
+		Exclusions:    exclusions,
+	}
+	if len(tolerated) > 0 {
+		selection.ToleratedUntil = &toleratedUntil
+	}
+
+	held, heldUntil := heldNodes(eligibleNodes, currentNodeNames, policy.Name, policy.Spec.MinSelectionDuration, now)
+	if len(held) > 0 {
+		selection.HeldUntil = &heldUntil
 	}
 
-	// Filter to only include Ready nodes
-	readyNodes := utils.FilterReadyNodes(nodes)
-	if len(readyNodes) == 0 {
-		return []corev1.Node{}, nil
+	if len(eligibleNodes) == 0 {
+		return selection, nil
 	}
 
-	nodeCopies := make([]corev1.Node, len(readyNodes))
-	copy(nodeCopies, readyNodes)
+	nodeCopies := make([]corev1.Node, len(eligibleNodes))
+	copy(nodeCopies, eligibleNodes)
 
 	switch strategy.Type {
-	case "oldest":
-		sort.Slice(nodeCopies, func(i, j int) bool {
-			return nodeCopies[i].CreationTimestamp.Before(&nodeCopies[j].CreationTimestamp)
-		})
+	case "", "oldest":
+		sortNodesByCreation(nodeCopies, true)
+		nodeCopies = preferNodes(nodeCopies, preferred)
 	case "newest":
-		sort.Slice(nodeCopies, func(i, j int) bool {
-			return nodeCopies[j].CreationTimestamp.Before(&nodeCopies[i].CreationTimestamp)
-		})
+		sortNodesByCreation(nodeCopies, false)
+		nodeCopies = preferNodes(nodeCopies, preferred)
 	case "random":
 		rand.Shuffle(len(nodeCopies), func(i, j int) {
 			nodeCopies[i], nodeCopies[j] = nodeCopies[j], nodeCopies[i]
 		})
+		nodeCopies = preferNodes(nodeCopies, preferred)
+	case "spread":
+		nodeCopies, err = spreadNodes(nodeCopies, strategy, preferred)
+		if err != nil {
+			return NodeSelection{}, err
+		}
+	case "score":
+		topologyKey := strategy.TopologyKey
+		if topologyKey == "" {
+			topologyKey = constants.DefaultTopologyKey
+		}
+		nodeCopies = scoreNodes(nodeCopies, nodeScorers(strategy.Score, topologyKey, now))
+		nodeCopies = preferNodes(nodeCopies, preferred)
 	default:
-		return nil, fmt.Errorf("unsupported strategy type: %s", strategy.Type)
+		return NodeSelection{}, fmt.Errorf("unsupported strategy type: %s", strategy.Type)
 	}
 
-	count := int(strategy.Count)
+	// Nodes within the minimum selection duration take precedence over the strategy order
+	nodeCopies = preferNodes(nodeCopies, held)
+
+	count := desiredCount
 	if count > len(nodeCopies) {
 		count = len(nodeCopies)
 	}
 
-	return nodeCopies[:count], nil
+	selection.Nodes = nodeCopies[:count]
+	return selection, nil
+}
+
+// toleratedNotReadyNodes returns the currently selected NotReady nodes that have been NotReady for less than
+// the toleration, along with the earliest time one of them stops being tolerated
+func toleratedNotReadyNodes(nodes []corev1.Node, currentNodeNames []string, toleration *metav1.Duration, now time.Time) (map[string]bool, time.Time) {
+	if toleration == nil || toleration.Duration <= 0 {
+		return nil, time.Time{}
+	}
+
+	current := make(map[string]bool, len(currentNodeNames))
+	for _, name := range currentNodeNames {
+		current[name] = true
+	}
+
+	tolerated := map[string]bool{}
+	var until time.Time
+	for _, node := range nodes {
+		since, notReady := utils.NotReadySince(&node)
+		if !notReady || !current[node.Name] {
+			continue
+		}
+
+		expiry := since.Add(toleration.Duration)
+		if !now.Before(expiry) {
+			continue
+		}
+		tolerated[node.Name] = true
+		if until.IsZero() || expiry.Before(until) {
+			until = expiry
+		}
+	}
+
+	return tolerated, until
+}
+
+// sortNodesByCreation sorts nodes by creation time, breaking ties by name
+func sortNodesByCreation(nodes []corev1.Node, oldestFirst bool) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ti, tj := nodes[i].CreationTimestamp, nodes[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return nodes[i].Name < nodes[j].Name
+		}
+		if oldestFirst {
+			return ti.Before(&tj)
+		}
+		return tj.Before(&ti)
+	})
+}
+
+// preferredNodes returns the set of nodes a sticky strategy keeps while they remain eligible
+// It returns nil when the strategy is not sticky
+func preferredNodes(strategy nlpv1alpha1.NodeLabelPolicyStrategy, currentNodeNames []string) (map[string]bool, error) {
+	switch strategy.Stickiness {
+	case "", "none":
+		return nil, nil
+	case "sticky":
+		preferred := make(map[string]bool, len(currentNodeNames))
+		for _, name := range currentNodeNames {
+			preferred[name] = true
+		}
+		return preferred, nil
+	default:
+		return nil, fmt.Errorf("unsupported stickiness: %s", strategy.Stickiness)
+	}
+}
+
+// preferNodes moves preferred nodes to the front, keeping the existing order within both groups
+func preferNodes(nodes []corev1.Node, preferred map[string]bool) []corev1.Node {
+	if len(preferred) == 0 {
+		return nodes
+	}
+
+	ordered := make([]corev1.Node, 0, len(nodes))
+	var others []corev1.Node
+	for _, node := range nodes {
+		if preferred[node.Name] {
+			ordered = append(ordered, node)
+		} else {
+			others = append(others, node)
+		}
+	}
+
+	return append(ordered, others...)
+}
+
+// spreadNodes orders nodes round-robin across the domains of the strategy's topology key,
+// so that selecting any number of nodes from the front spreads them as evenly as possible.
+// Within a domain nodes are ordered by the tie-breaker. Preferred nodes come first, spread across
+// their own domains, and the other nodes then fill the domains holding the fewest nodes so far.
+// Nodes without the topology label are ordered the same way after the nodes of all domains, so
+// they are only selected once the domains are exhausted.
+func spreadNodes(nodes []corev1.Node, strategy nlpv1alpha1.NodeLabelPolicyStrategy, preferred map[string]bool) ([]corev1.Node, error) {
+	topologyKey := strategy.TopologyKey
+	if topologyKey == "" {
+		topologyKey = constants.DefaultTopologyKey
+	}
+
+	var oldestFirst bool
+	switch strategy.TieBreaker {
+	case "", "oldest":
+		oldestFirst = true
+	case "newest":
+		oldestFirst = false
+	default:
+		return nil, fmt.Errorf("unsupported tie-breaker: %s", strategy.TieBreaker)
+	}
+
+	sortNodesByCreation(nodes, oldestFirst)
+
+	preferredDomains := make(map[string][]corev1.Node)
+	otherDomains := make(map[string][]corev1.Node)
+	var preferredUnlabeled, otherUnlabeled []corev1.Node
+	for _, node := range nodes {
+		domain := node.Labels[topologyKey]
+		switch {
+		case domain == "" && preferred[node.Name]:
+			preferredUnlabeled = append(preferredUnlabeled, node)
+		case domain == "":
+			otherUnlabeled = append(otherUnlabeled, node)
+		case preferred[node.Name]:
+			preferredDomains[domain] = append(preferredDomains[domain], node)
+		default:
+			otherDomains[domain] = append(otherDomains[domain], node)
+		}
+	}
+
+	taken := make(map[string]int)
+	spread := make([]corev1.Node, 0, len(nodes))
+	spread = append(spread, fillDomains(preferredDomains, taken)...)
+	spread = append(spread, preferredUnlabeled...)
+	spread = append(spread, fillDomains(otherDomains, taken)...)
+	return append(spread, otherUnlabeled...), nil
+}
+
+// fillDomains takes the nodes of the domains one at a time from the domain with the fewest nodes taken so far,
+// ties broken by domain name, and counts the taken nodes per domain
+func fillDomains(domains map[string][]corev1.Node, taken map[string]int) []corev1.Node {
+	domainNames := make([]string, 0, len(domains))
+	remaining := 0
+	for name, domainNodes := range domains {
+		domainNames = append(domainNames, name)
+		remaining += len(domainNodes)
+	}
+	sort.Strings(domainNames)
+
+	filled := make([]corev1.Node, 0, remaining)
+	next := make(map[string]int, len(domains))
+	for len(filled) < remaining {
+		fewest := ""
+		for _, name := range domainNames {
+			if next[name] < len(domains[name]) && (fewest == "" || taken[name] < taken[fewest]) {
+				fewest = name
+			}
+		}
+		filled = append(filled, domains[fewest][next[fewest]])
+		next[fewest]++
+		taken[fewest]++
+	}
+
+	return filled
+}
+
+// StrategyCount returns the strategy count, or the default count when it is not set
+func StrategyCount(strategy nlpv1alpha1.NodeLabelPolicyStrategy) *intstr.IntOrString {
+	return intstr.ValueOrDefault(strategy.Count, intstr.FromInt32(constants.DefaultCount))
+}
+
+// resolveCount converts the strategy count into a number of nodes for the given number of eligible nodes
+// Percentages are rounded according to the strategy rounding mode and the result is clamped to MinCount and MaxCount
+// A count or percentage that is not positive is rejected
+func resolveCount(strategy nlpv1alpha1.NodeLabelPolicyStrategy, eligibleNodes int) (int, error) {
+	var roundUp bool
+	switch strategy.Rounding {
+	case "", "up":
+		roundUp = true
+	case "down":
+		roundUp = false
+	default:
+		return 0, fmt.Errorf("unsupported rounding mode: %s", strategy.Rounding)
+	}
+
+	strategyCount := StrategyCount(strategy)
+	count, err := intstr.GetScaledValueFromIntOrPercent(strategyCount, eligibleNodes, roundUp)
+	if err != nil {
+		return 0, fmt.Errorf("invalid strategy count %q: %w", strategyCount.String(), err)
+	}
+	// A count or percentage below 1 is a misconfiguration rather than a request to select no nodes,
+	// so it must not release the labels of every node. Scaling against 100 yields the declared value.
+	if declared, _ := intstr.GetScaledValueFromIntOrPercent(strategyCount, 100, true); declared < 1 {
+		return 0, fmt.Errorf("invalid strategy count %q: must be greater than 0", strategyCount.String())
+	}
+
+	if strategy.MinCount != nil && count < int(*strategy.MinCount) {
+		count = int(*strategy.MinCount)
+	}
+	if strategy.MaxCount != nil && count > int(*strategy.MaxCount) {
+		count = int(*strategy.MaxCount)
+	}
+
+	return count, nil
 }
 
-// ApplyLabelsToNode applies labels to a specific node
-func (h *nodeLabelPolicyHandler) ApplyLabelsToNode(ctx context.Context, node *corev1.Node, labels map[string]string, managedByLabelKey string) error {
+// ApplyLabelsToNode applies labels and annotations to a specific node
+// Labels and annotations are owned through server-side apply by the policy's field manager, and nodes on
+// which the policy already owns exactly the desired labels and annotations are left untouched. Labels owned
+// by another field manager are reported and then taken over, while annotations the node already carries
+// without the policy owning them are left alone. The applied label keys and their prior values are recorded
+// on the node, and keys the policy no longer applies get their prior value back.
+func (h *nodeLabelPolicyHandler) ApplyLabelsToNode(ctx context.Context, node *corev1.Node, policyName string, labels map[string]string, annotations map[string]string) (ApplyResult, error) {
+	fieldManager := FieldManager(policyName)
+	desired := desiredMetadata(node, policyName, labels, annotations)
+
+	if len(desired.restore) == 0 && ownsMetadata(node, fieldManager, desired.labels, desired.annotations) {
+		return ApplyResult{}, nil
+	}
+
+	// Restoring a prior value first hands the key back to its previous owner, so the apply below leaves it in place
+	if err := h.restoreNodeLabels(ctx, node, desired.restore); err != nil {
+		return ApplyResult{}, &NodeUpdateError{Node: node, Action: "restore labels on", Err: err}
+	}
+
+	var result ApplyResult
+	err := h.client.Patch(ctx, metadataApplyObject(node.Name, desired.labels, desired.annotations), client.Apply, client.FieldOwner(fieldManager))
+	if apierrors.IsConflict(err) {
+		result.Conflicts = applyConflicts(err)
+		err = h.client.Patch(ctx, metadataApplyObject(node.Name, desired.labels, desired.annotations), client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+	}
+	if err != nil {
+		return result, &NodeUpdateError{Node: node, Action: "update", Err: err}
+	}
+	result.Changed = true
+	result.Labeled = node.Labels[ManagedByLabelKey(policyName)] != managedByLabelValue
+
 	if node.Labels == nil {
 		node.Labels = make(map[string]string)
 	}
-
-	for key, value := range labels {
+	for key, value := range desired.labels {
 		node.Labels[key] = value
 	}
+	if len(desired.annotations) > 0 && node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	for key, value := range desired.annotations {
+		node.Annotations[key] = value
+	}
+
+	return result, nil
+}
 
-	node.Labels[managedByLabelKey] = managedByLabelValue
+// ApplyTaintsToNode adds the policy's taints to a specific node and removes the taints it no longer sets
+// The taints the policy added are recorded in an annotation on the node, so that only those are ever removed.
+// The node is patched with an optimistic lock, since the taints are replaced as a whole.
+func (h *nodeLabelPolicyHandler) ApplyTaintsToNode(ctx context.Context, node *corev1.Node, policyName string, taints []corev1.Taint) (bool, error) {
+	changed, err := h.patchNode(ctx, node, func(node *corev1.Node) bool { return setTaints(node, policyName, taints) },
+		client.MergeFromWithOptimisticLock{})
+	if err != nil {
+		return false, &NodeUpdateError{Node: node, Action: "taint", Err: err}
+	}
+	return changed, nil
+}
 
-	if err := h.client.Update(ctx, node); err != nil {
-		return fmt.Errorf("failed to update node %s: %w", node.Name, err)
+// restoreNodeLabels sets labels of the node back to their prior values, each with a JSON patch that only applies
+// while the node still carries the value the restore was planned from. Labels someone else changed in the meantime
+// keep their value, and patches need no resourceVersion, so that unrelated changes to the node do not conflict.
+func (h *nodeLabelPolicyHandler) restoreNodeLabels(ctx context.Context, node *corev1.Node, restore map[string]string) error {
+	keys := make([]string, 0, len(restore))
+	for key := range restore {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
+	for _, key := range keys {
+		data, err := restoreLabelPatch(node, key, restore[key])
+		if err != nil {
+			return err
+		}
+		err = h.client.Patch(ctx, node, client.RawPatch(types.JSONPatchType, data))
+		if apierrors.IsInvalid(err) {
+			// The test operation failed, so the label no longer carries the value the restore was planned from
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if node.Labels == nil {
+			node.Labels = make(map[string]string)
+		}
+		node.Labels[key] = restore[key]
+	}
 	return nil
 }
 
-// RemoveLabelsFromUnselectedNodes removes labels from nodes that are not selected
-func (h *nodeLabelPolicyHandler) RemoveLabelsFromUnselectedNodes(ctx context.Context, allNodes []corev1.Node, selectedNodes []corev1.Node, managedByLabelKey string, policyLabels map[string]string) error {
-	selectedNodeNames := make(map[string]bool)
+// patchNode changes the node with mutate and, if it reports a change, patches the node with a merge patch
+// Mutations replacing the taints, which the merge patch sets as a whole list, pass client.MergeFromWithOptimisticLock
+// so that they do not drop taints added concurrently. It reports whether the node was patched, and the node is left
+// unchanged if the patch fails
+func (h *nodeLabelPolicyHandler) patchNode(ctx context.Context, node *corev1.Node, mutate func(node *corev1.Node) bool, opts ...client.MergeFromOption) (bool, error) {
+	original := node.DeepCopy()
+	if !mutate(node) {
+		return false, nil
+	}
+
+	if err := h.client.Patch(ctx, node, client.MergeFromWithOptions(original, opts...)); err != nil {
+		original.DeepCopyInto(node)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DetectPolicyConflicts returns the label keys the policy leaves to higher-priority policies on the given nodes
+func (h *nodeLabelPolicyHandler) DetectPolicyConflicts(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, nodes []corev1.Node) (PolicyConflicts, error) {
+	policyList := &nlpv1alpha1.NodeLabelPolicyList{}
+	if err := h.client.List(ctx, policyList); err != nil {
+		return nil, fmt.Errorf("failed to list NodeLabelPolicies: %w", err)
+	}
+
+	return NewLabelKeyIndex(policyList.Items).Conflicts(policy, nodes), nil
+}
+
+// PlanLabelChanges computes the label changes that enforce the selection without changing any node
+// Templated label values are rendered per selected node, with the index recorded on the node when the policy labeled it
+// or the lowest free index for a newly selected node.
+// Selected nodes on which the policy already owns exactly the desired labels, annotations and taints are left out of the plan,
+// and label moves beyond the policy's rollout limits are left pending
+func (h *nodeLabelPolicyHandler) PlanLabelChanges(policy *nlpv1alpha1.NodeLabelPolicy, allNodes []corev1.Node, selectedNodes []corev1.Node, conflicts PolicyConflicts) (LabelPlan, error) {
+	fieldManager := FieldManager(policy.Name)
+	managedByLabelKey := ManagedByLabelKey(policy.Name)
+
+	templated := hasLabelTemplates(policy.Spec.Labels)
+	indexes := templateIndexes(policy.Name, selectedNodes)
+	now := time.Now()
+
+	var plan LabelPlan
+	selectedNodeNames := make(map[string]bool, len(selectedNodes))
 	for _, node := range selectedNodes {
 		selectedNodeNames[node.Name] = true
+
+		nodeAnnotations := policy.Spec.Annotations
+		if duration := policy.Spec.MinSelectionDuration; duration != nil && duration.Duration > 0 {
+			nodeAnnotations = selectedAtAnnotation(&node, policy.Name, nodeAnnotations, now)
+		}
+		if templated {
+			nodeAnnotations = indexAnnotation(policy.Name, nodeAnnotations, indexes[node.Name])
+		}
+
+		nodeLabels, err := utils.RenderLabelTemplates(conflicts.Without(node.Name, policy.Spec.Labels), &node, indexes[node.Name])
+		if err != nil {
+			return LabelPlan{}, err
+		}
+		taintsChanged := setTaints(node.DeepCopy(), policy.Name, policy.Spec.Taints)
+		desired := desiredMetadata(&node, policy.Name, nodeLabels, nodeAnnotations)
+		owned := len(desired.restore) == 0 && ownsMetadata(&node, fieldManager, desired.labels, desired.annotations)
+		if taintsChanged || !owned {
+			plan.Apply = append(plan.Apply, PlannedLabels{
+				Node:        node,
+				Labels:      nodeLabels,
+				Annotations: nodeAnnotations,
+				Taints:      policy.Spec.Taints,
+			})
+		}
 	}
 
+	labeledCount := 0
 	for _, node := range allNodes {
-		if selectedNodeNames[node.Name] {
+		if node.Labels[managedByLabelKey] != managedByLabelValue {
 			continue
 		}
+		labeledCount++
+		if !selectedNodeNames[node.Name] {
+			plan.Remove = append(plan.Remove, node)
+		}
+	}
 
-		if node.Labels != nil && node.Labels[managedByLabelKey] == managedByLabelValue {
-			nodeCopy := node.DeepCopy()
-
-			delete(nodeCopy.Labels, managedByLabelKey)
+	return limitRollout(plan, policy.Spec.Rollout, policy.Name, labeledCount, len(selectedNodes))
+}
 
-			for key := range policyLabels {
-				delete(nodeCopy.Labels, key)
-			}
+// RemoveLabelsFromNodes removes the policy's labels and taints from the given nodes, skipping nodes the policy does not manage
+// Labels the policy took over get the value they had before back
+// On failure the nodes released before the failing node are returned along with the error
+func (h *nodeLabelPolicyHandler) RemoveLabelsFromNodes(ctx context.Context, nodes []corev1.Node, policyName string, policyLabels map[string]string) ([]corev1.Node, error) {
+	managedByLabelKey := ManagedByLabelKey(policyName)
 
-			if err := h.client.Update(ctx, nodeCopy); err != nil {
-				return fmt.Errorf("failed to remove labels from node %s: %w", node.Name, err)
+	var released []corev1.Node
+	for _, node := range nodes {
+		if node.Labels != nil && node.Labels[managedByLabelKey] == managedByLabelValue {
+			if err := h.releaseNode(ctx, &node, policyName, policyLabels); err != nil {
+				return released, &NodeUpdateError{Node: &node, Action: "remove labels from", Err: err}
 			}
+			released = append(released, node)
 		}
 	}
 
-	return nil
+	return released, nil
 }
 
-// CleanupLabelsFromAllNodes removes all labels related to a policy from all nodes
-// If policyLabels is nil, only managed-by and policy-prefix labels are removed
-func (h *nodeLabelPolicyHandler) CleanupLabelsFromAllNodes(ctx context.Context, policyName string, policyLabels map[string]string) error {
+// CleanupLabelsFromAllNodes removes all labels and taints related to a policy from all nodes
+// Labels the policy took over get the value they had before back
+// If policyLabels is nil, only managed-by and policy-prefix labels are removed from nodes labeled
+// before their ownership was tracked
+func (h *nodeLabelPolicyHandler) CleanupLabelsFromAllNodes(ctx context.Context, policyName string, policyLabels map[string]string) ([]corev1.Node, error) {
 	nodeList := &corev1.NodeList{}
 	if err := h.client.List(ctx, nodeList); err != nil {
-		return fmt.Errorf("failed to list nodes: %w", err)
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
 
-	managedByLabelKey := fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, policyName)
-	policyLabelPrefix := fmt.Sprintf("%s.%s/", constants.ManagedByLabelPrefix, policyName)
+	managedByLabelKey := ManagedByLabelKey(policyName)
 
+	var released []corev1.Node
 	for _, node := range nodeList.Items {
 		if node.Labels != nil && node.Labels[managedByLabelKey] == managedByLabelValue {
-			nodeCopy := node.DeepCopy()
+			if err := h.releaseNode(ctx, &node, policyName, policyLabels); err != nil {
+				return released, &NodeUpdateError{Node: &node, Action: "cleanup labels from", Err: err}
+			}
+			released = append(released, node)
+		}
+	}
 
-			// Remove the managed-by label
-			delete(nodeCopy.Labels, managedByLabelKey)
+	return released, nil
+}
 
-			// Remove any labels with policy-specific prefix
-			for key := range nodeCopy.Labels {
-				if strings.HasPrefix(key, policyLabelPrefix) {
-					delete(nodeCopy.Labels, key)
-				}
-			}
+// OrphanLabelsOnAllNodes stops tracking a policy's labels and taints on all nodes while leaving them in place
+// The managed-by label and the records of applied labels, added taints and selection time are removed and the policy's field manager gives up ownership of the labels,
+// so a later policy with the same name does not remove them
+func (h *nodeLabelPolicyHandler) OrphanLabelsOnAllNodes(ctx context.Context, policyName string) ([]corev1.Node, error) {
+	managedByLabelKey := ManagedByLabelKey(policyName)
+
+	nodeList := &corev1.NodeList{}
+	if err := h.client.List(ctx, nodeList, client.HasLabels{managedByLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	fieldManager := FieldManager(policyName)
+
+	var orphaned []corev1.Node
+	for _, node := range nodeList.Items {
+		if node.Labels[managedByLabelKey] != managedByLabelValue {
+			continue
+		}
 
-			// Remove policy-specific labels (safe with nil map)
-			for key := range policyLabels {
-				delete(nodeCopy.Labels, key)
+		nodeCopy := node.DeepCopy()
+		delete(nodeCopy.Labels, managedByLabelKey)
+		delete(nodeCopy.Annotations, TaintsAnnotationKey(policyName))
+		delete(nodeCopy.Annotations, LabelsAnnotationKey(policyName))
+		delete(nodeCopy.Annotations, SelectedAtAnnotationKey(policyName))
+		delete(nodeCopy.Annotations, IndexAnnotationKey(policyName))
+
+		nodeCopy.ManagedFields = nil
+		for _, entry := range node.ManagedFields {
+			if entry.Manager != fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply {
+				nodeCopy.ManagedFields = append(nodeCopy.ManagedFields, entry)
 			}
+		}
+		if len(nodeCopy.ManagedFields) == 0 {
+			// An empty list leaves the managed fields unchanged, a single empty entry clears them
+			nodeCopy.ManagedFields = []metav1.ManagedFieldsEntry{{}}
+		}
+
+		if err := h.client.Patch(ctx, nodeCopy, client.MergeFrom(&node)); err != nil {
+			return orphaned, &NodeUpdateError{Node: &node, Action: "orphan labels on", Err: err}
+		}
+		orphaned = append(orphaned, node)
+	}
+
+	return orphaned, nil
+}
+
+// releaseNode removes the taints the policy added to a node, restores the recorded prior values of the labels
+// it took over and drops the policy's labels and annotations by applying an empty configuration, so that only
+// those no other field manager owns are removed. Nodes labeled before ownership was tracked through server-side
+// apply still carry the managed-by label afterwards; their labels are removed by key.
+func (h *nodeLabelPolicyHandler) releaseNode(ctx context.Context, node *corev1.Node, policyName string, policyLabels map[string]string) error {
+	_, restore := planLabelRecord(node, policyName, nil, appliedKeys(node, FieldManager(policyName)).labels)
+	if _, err := h.patchNode(ctx, node, func(node *corev1.Node) bool { return setTaints(node, policyName, nil) },
+		client.MergeFromWithOptimisticLock{}); err != nil {
+		return err
+	}
+	if err := h.restoreNodeLabels(ctx, node, restore); err != nil {
+		return err
+	}
+
+	applied := metadataApplyObject(node.Name, nil, nil)
+	if err := h.client.Patch(ctx, applied, client.Apply, client.FieldOwner(FieldManager(policyName))); err != nil {
+		return err
+	}
+
+	managedByLabelKey := ManagedByLabelKey(policyName)
+	if applied.GetLabels()[managedByLabelKey] != managedByLabelValue {
+		return nil
+	}
+
+	nodeCopy := node.DeepCopy()
+
+	// Remove the managed-by label
+	delete(nodeCopy.Labels, managedByLabelKey)
+
+	// Remove any labels with policy-specific prefix
+	policyLabelPrefix := fmt.Sprintf("%s.%s/", constants.ManagedByLabelPrefix, policyName)
+	for key := range nodeCopy.Labels {
+		if strings.HasPrefix(key, policyLabelPrefix) {
+			delete(nodeCopy.Labels, key)
+		}
+	}
+
+	// Remove policy-specific labels (safe with nil map)
+	for key := range policyLabels {
+		delete(nodeCopy.Labels, key)
+	}
+
+	return h.client.Patch(ctx, nodeCopy, client.MergeFrom(node))
+}
+
+// metadataApplyObject builds the server-side apply configuration of a node's labels and annotations
+func metadataApplyObject(nodeName string, labels map[string]string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Node")
+	obj.SetName(nodeName)
+	if len(labels) > 0 {
+		obj.SetLabels(labels)
+	}
+	if len(annotations) > 0 {
+		obj.SetAnnotations(annotations)
+	}
+	return obj
+}
+
+// nodeMetadata is the metadata a policy applies to a node
+type nodeMetadata struct {
+	labels      map[string]string
+	annotations map[string]string
+	// restore are the prior values of recorded labels the policy no longer applies
+	restore map[string]string
+}
+
+// desiredMetadata returns the labels and annotations a policy applies to a node, including the record of its
+// labels, along with the prior label values to restore
+func desiredMetadata(node *corev1.Node, policyName string, labels map[string]string, annotations map[string]string) nodeMetadata {
+	fieldManager := FieldManager(policyName)
+	desired := nodeMetadata{
+		labels:      desiredNodeLabels(policyName, labels),
+		annotations: desiredNodeAnnotations(node, fieldManager, annotations),
+	}
+
+	var record labelRecord
+	record, desired.restore = planLabelRecord(node, policyName, labels, appliedKeys(node, fieldManager).labels)
+	if len(record) > 0 {
+		desired.annotations[LabelsAnnotationKey(policyName)] = record.String()
+	}
+
+	return desired
+}
+
+// desiredNodeLabels returns the labels a policy applies to a node, including its managed-by label
+func desiredNodeLabels(policyName string, labels map[string]string) map[string]string {
+	desiredLabels := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		desiredLabels[key] = value
+	}
+	desiredLabels[ManagedByLabelKey(policyName)] = managedByLabelValue
+	return desiredLabels
+}
+
+// desiredNodeAnnotations returns the annotations a policy applies to a node, leaving out annotations the
+// node already carries without the policy owning them, so that pre-existing annotations are never taken over
+func desiredNodeAnnotations(node *corev1.Node, fieldManager string, annotations map[string]string) map[string]string {
+	owned := appliedKeys(node, fieldManager)
+
+	desiredAnnotations := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if _, exists := node.Annotations[key]; exists && !owned.annotations[key] {
+			continue
+		}
+		desiredAnnotations[key] = value
+	}
+	return desiredAnnotations
+}
 
-			if err := h.client.Update(ctx, nodeCopy); err != nil {
-				return fmt.Errorf("failed to cleanup labels from node %s: %w", node.Name, err)
+// ownsMetadata reports whether the field manager already owns exactly the desired labels and annotations on the node
+func ownsMetadata(node *corev1.Node, fieldManager string, desiredLabels map[string]string, desiredAnnotations map[string]string) bool {
+	owned := appliedKeys(node, fieldManager)
+	return owned.found &&
+		sameKeys(owned.labels, desiredLabels) && hasValues(node.Labels, desiredLabels) &&
+		sameKeys(owned.annotations, desiredAnnotations) && hasValues(node.Annotations, desiredAnnotations)
+}
+
+// ownedKeys are the metadata keys a field manager owns on a node through server-side apply
+type ownedKeys struct {
+	labels      map[string]bool
+	annotations map[string]bool
+	found       bool
+}
+
+// appliedKeys returns the label and annotation keys a field manager owns on the node through server-side apply
+func appliedKeys(node *corev1.Node, fieldManager string) ownedKeys {
+	for _, entry := range node.ManagedFields {
+		if entry.Manager != fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+
+		var fields struct {
+			Metadata struct {
+				Labels      map[string]json.RawMessage `json:"f:labels"`
+				Annotations map[string]json.RawMessage `json:"f:annotations"`
+			} `json:"f:metadata"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return ownedKeys{}
+		}
+
+		return ownedKeys{
+			labels:      fieldKeys(fields.Metadata.Labels),
+			annotations: fieldKeys(fields.Metadata.Annotations),
+			found:       true,
+		}
+	}
+
+	return ownedKeys{}
+}
+
+// fieldKeys returns the keys of a managed fields map without their "f:" prefix
+func fieldKeys(fields map[string]json.RawMessage) map[string]bool {
+	keys := make(map[string]bool, len(fields))
+	for field := range fields {
+		keys[strings.TrimPrefix(field, "f:")] = true
+	}
+	return keys
+}
+
+// applyConflicts describes the fields of a server-side apply conflict error
+// Fields owned by other policies are left out: the policy only applies keys it does not leave to a
+// higher-priority policy, so taking them over from a lower-priority one is the expected outcome.
+func applyConflicts(err error) []string {
+	var conflicts []string
+	causes := 0
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type != metav1.CauseTypeFieldManagerConflict {
+				continue
+			}
+			causes++
+			if strings.Contains(cause.Message, fmt.Sprintf(`conflict with "%s/`, constants.ManagedByLabelPrefix)) {
+				continue
 			}
+			conflicts = append(conflicts, fmt.Sprintf("%s (%s)", cause.Field, cause.Message))
 		}
 	}
 
-	return nil
+	if causes == 0 {
+		conflicts = append(conflicts, err.Error())
+	}
+
+	return conflicts
+}
+
+// hasValues reports whether the current map already contains all the desired entries with the same values
+func hasValues(current map[string]string, desired map[string]string) bool {
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// sameKeys reports whether the set of keys matches the keys of the map
+func sameKeys(keys map[string]bool, values map[string]string) bool {
+	if len(keys) != len(values) {
+		return false
+	}
+	for key := range values {
+		if !keys[key] {
+			return false
+		}
+	}
+	return true
 }
 
 // UpdatePolicyStatus updates the status of a NodeLabelPolicy
-func (h *nodeLabelPolicyHandler) UpdatePolicyStatus(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, selectedNodeNames []string) error {
-	policy.Status.SelectedNodes = selectedNodeNames
-	policy.Status.LastReconcileTime = &metav1.Time{Time: metav1.Now().Time}
+// When the reconciliation failed only the conditions are updated, since the selection was not fully applied.
+// Dry-run policies report the planned changes and keep the nodes that currently carry their labels, and
+// suspended policies keep their last selection unless their labels were released.
+func (h *nodeLabelPolicyHandler) UpdatePolicyStatus(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, result ReconcileResult) error {
+	switch {
+	case result.Err != nil:
+		// Keep the selection of the last successful reconciliation
+	case policy.Spec.Suspend:
+		if policy.Spec.ReleaseOnSuspend {
+			policy.Status.SelectedNodes = nil
+			policy.Status.SelectedCount = 0
+		}
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               nlpv1alpha1.ConditionProgressing,
+			Status:             metav1.ConditionFalse,
+			Reason:             nlpv1alpha1.ReasonSuspended,
+			Message:            "Nodes are not changed while the policy is suspended",
+			ObservedGeneration: policy.Generation,
+		})
+	default:
+		policy.Status.DesiredCount = result.Selection.DesiredCount
+		policy.Status.EligibleCount = result.Selection.EligibleCount
+		policy.Status.ExcludedNodes = result.Selection.Exclusions.DeepCopy()
+		policy.Status.LastReconcileTime = &metav1.Time{Time: metav1.Now().Time}
+		policy.Status.Rollout = nil
+		if result.Plan.RollingOut() {
+			policy.Status.Rollout = &nlpv1alpha1.NodeLabelPolicyPlan{
+				NodesToLabel:   result.Plan.PendingLabel,
+				NodesToUnlabel: result.Plan.PendingUnlabel,
+			}
+		}
+
+		if policy.Spec.DryRun {
+			policy.Status.Plan = &nlpv1alpha1.NodeLabelPolicyPlan{
+				NodesToLabel:   result.Plan.NodesToLabel(),
+				NodesToUnlabel: result.Plan.NodesToUnlabel(),
+			}
+			meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+				Type:               nlpv1alpha1.ConditionProgressing,
+				Status:             metav1.ConditionFalse,
+				Reason:             nlpv1alpha1.ReasonDryRun,
+				Message:            "Nodes are not changed in dry-run mode",
+				ObservedGeneration: policy.Generation,
+			})
+		} else {
+			previousNodes := policy.Status.SelectedNodes
+
+			policy.Status.SelectedNodes = labeledNodeNames(result)
+			policy.Status.SelectedCount = int32(len(policy.Status.SelectedNodes))
+			policy.Status.Plan = nil
+
+			setProgressingCondition(policy, previousNodes)
+		}
+	}
+
+	policy.Status.ObservedGeneration = policy.Generation
+	setSuspendedCondition(policy)
+	setReadyAndDegradedConditions(policy, result)
 
 	if err := h.client.Status().Update(ctx, policy); err != nil {
 		return fmt.Errorf("failed to update NodeLabelPolicy status: %w", err)
@@ -192,3 +1052,150 @@ func (h *nodeLabelPolicyHandler) UpdatePolicyStatus(ctx context.Context, policy
 
 	return nil
 }
+
+// setProgressingCondition reports whether the policy's labels moved between nodes
+func setProgressingCondition(policy *nlpv1alpha1.NodeLabelPolicy, previousNodes []string) {
+	condition := metav1.Condition{
+		Type:               nlpv1alpha1.ConditionProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             nlpv1alpha1.ReasonReconciled,
+		Message:            "Selected nodes are unchanged",
+		ObservedGeneration: policy.Generation,
+	}
+
+	switch rollout := policy.Status.Rollout; {
+	case rollout != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = nlpv1alpha1.ReasonRollingOut
+		condition.Message = fmt.Sprintf("Rollout has yet to label nodes [%s] and unlabel nodes [%s]",
+			strings.Join(rollout.NodesToLabel, ", "), strings.Join(rollout.NodesToUnlabel, ", "))
+	case !sameNodeNames(previousNodes, policy.Status.SelectedNodes):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = nlpv1alpha1.ReasonSelectionChanged
+		condition.Message = fmt.Sprintf("Selected nodes changed to [%s]", strings.Join(policy.Status.SelectedNodes, ", "))
+	}
+
+	meta.SetStatusCondition(&policy.Status.Conditions, condition)
+}
+
+// setSuspendedCondition reports whether the policy is suspended
+func setSuspendedCondition(policy *nlpv1alpha1.NodeLabelPolicy) {
+	condition := metav1.Condition{
+		Type:               nlpv1alpha1.ConditionSuspended,
+		Status:             metav1.ConditionFalse,
+		Reason:             nlpv1alpha1.ReasonReconciled,
+		Message:            "Policy is reconciled",
+		ObservedGeneration: policy.Generation,
+	}
+
+	if policy.Spec.Suspend {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = nlpv1alpha1.ReasonSuspended
+		condition.Message = "Policy is suspended, labels are left on the nodes that carry them"
+		if policy.Spec.ReleaseOnSuspend {
+			condition.Message = "Policy is suspended, labels are released from all nodes"
+		}
+	}
+
+	meta.SetStatusCondition(&policy.Status.Conditions, condition)
+}
+
+// setReadyAndDegradedConditions reports whether the policy is fully enforced and, if not, why
+func setReadyAndDegradedConditions(policy *nlpv1alpha1.NodeLabelPolicy, result ReconcileResult) {
+	ready := metav1.Condition{
+		Type:               nlpv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             nlpv1alpha1.ReasonReconciled,
+		Message:            fmt.Sprintf("Labels are applied to %d of %d desired nodes", policy.Status.SelectedCount, policy.Status.DesiredCount),
+		ObservedGeneration: policy.Generation,
+	}
+	degraded := metav1.Condition{
+		Type:               nlpv1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             nlpv1alpha1.ReasonReconciled,
+		Message:            "Policy is enforced",
+		ObservedGeneration: policy.Generation,
+	}
+
+	switch {
+	case result.Err != nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = nlpv1alpha1.ReasonNodeUpdateFailed
+		ready.Message = result.Err.Error()
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = nlpv1alpha1.ReasonNodeUpdateFailed
+		degraded.Message = result.Err.Error()
+	case int32(len(result.Selection.Nodes)) < result.Selection.DesiredCount:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = nlpv1alpha1.ReasonInsufficientEligibleNodes
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = nlpv1alpha1.ReasonInsufficientEligibleNodes
+		degraded.Message = fmt.Sprintf("Only %d of %d desired nodes are eligible", policy.Status.EligibleCount, policy.Status.DesiredCount)
+	case len(result.PolicyConflicts) > 0:
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = nlpv1alpha1.ReasonPolicyConflict
+		degraded.Message = fmt.Sprintf("Label keys [%s] are left to higher-priority policies [%s] on nodes [%s]",
+			strings.Join(result.PolicyConflicts.Keys(), ", "),
+			strings.Join(result.PolicyConflicts.Winners(), ", "),
+			strings.Join(result.PolicyConflicts.NodeNames(), ", "))
+	case len(result.ConflictingNodes) > 0:
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = nlpv1alpha1.ReasonLabelConflict
+		degraded.Message = fmt.Sprintf("Labels owned by another field manager were taken over on nodes [%s]", strings.Join(result.ConflictingNodes, ", "))
+	}
+
+	switch {
+	case result.Err != nil:
+		// The failure takes precedence over the mode of the policy
+	case policy.Spec.Suspend:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = nlpv1alpha1.ReasonSuspended
+		ready.Message = "Policy is suspended"
+		degraded.Message = "Policy is suspended"
+	case policy.Spec.DryRun:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = nlpv1alpha1.ReasonDryRun
+		ready.Message = fmt.Sprintf("Dry run plans to label nodes [%s] and unlabel nodes [%s]",
+			strings.Join(result.Plan.NodesToLabel(), ", "), strings.Join(result.Plan.NodesToUnlabel(), ", "))
+	}
+
+	meta.SetStatusCondition(&policy.Status.Conditions, ready)
+	meta.SetStatusCondition(&policy.Status.Conditions, degraded)
+}
+
+// labeledNodeNames returns the names of the nodes carrying the policy's labels after the reconciliation,
+// i.e. the selected nodes and the deselected nodes the rollout limits keep labeled, without the selected nodes
+// still waiting for the labels
+func labeledNodeNames(result ReconcileResult) []string {
+	pending := make(map[string]bool, len(result.Plan.PendingLabel))
+	for _, name := range result.Plan.PendingLabel {
+		pending[name] = true
+	}
+
+	names := make([]string, 0, len(result.Selection.Nodes))
+	for _, node := range result.Selection.Nodes {
+		if !pending[node.Name] {
+			names = append(names, node.Name)
+		}
+	}
+	return append(names, result.Plan.PendingUnlabel...)
+}
+
+// sameNodeNames reports whether both slices contain the same node names, ignoring order
+func sameNodeNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	names := make(map[string]bool, len(a))
+	for _, name := range a {
+		names[name] = true
+	}
+	for _, name := range b {
+		if !names[name] {
+			return false
+		}
+	}
+
+	return true
+}
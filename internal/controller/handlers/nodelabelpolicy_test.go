@@ -18,6 +18,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -25,14 +26,19 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
 	"github.com/jivvon/node-label-controller/internal/constants"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
 )
 
 func TestHandlers(t *testing.T) {
@@ -109,6 +115,61 @@ func (m *mockStatusWriter) Patch(ctx context.Context, obj client.Object, patch c
 	return nil
 }
 
+// testNode returns a Ready node created age ago with a copy of the given labels
+func testNode(name string, age time.Duration, labels map[string]string) corev1.Node {
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			Labels:            map[string]string{},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	for key, value := range labels {
+		node.Labels[key] = value
+	}
+	return node
+}
+
+// labeledBy returns the node carrying the managed-by labels of the given policies
+func labeledBy(node corev1.Node, policyNames ...string) corev1.Node {
+	for _, policyName := range policyNames {
+		node.Labels[ManagedByLabelKey(policyName)] = managedByLabelValue
+	}
+	return node
+}
+
+// testNodes returns Ready nodes with the given names
+func testNodes(names ...string) []corev1.Node {
+	nodes := make([]corev1.Node, len(names))
+	for i, name := range names {
+		nodes[i] = testNode(name, 0, nil)
+	}
+	return nodes
+}
+
+// labeledNodes returns Ready nodes with the given names carrying the policy's managed-by label
+func labeledNodes(policyName string, names ...string) []corev1.Node {
+	nodes := testNodes(names...)
+	for i := range nodes {
+		nodes[i] = labeledBy(nodes[i], policyName)
+	}
+	return nodes
+}
+
+// testPolicy returns a policy setting the given labels with the given priority
+func testPolicy(name string, priority int32, labels map[string]string) nlpv1alpha1.NodeLabelPolicy {
+	return nlpv1alpha1.NodeLabelPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: nlpv1alpha1.NodeLabelPolicySpec{
+			Labels:   labels,
+			Priority: priority,
+		},
+	}
+}
+
 var _ = Describe("NodeLabelPolicyHandler", func() {
 	var (
 		handler NodeLabelPolicyHandler
@@ -124,6 +185,13 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 	Describe("SelectNodes", func() {
 		var nodes []corev1.Node
 
+		policyWith := func(strategy nlpv1alpha1.NodeLabelPolicyStrategy) *nlpv1alpha1.NodeLabelPolicy {
+			return &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       nlpv1alpha1.NodeLabelPolicySpec{Strategy: strategy},
+			}
+		}
+
 		BeforeEach(func() {
 			now := metav1.Now()
 			oldTime := metav1.Time{Time: now.Add(-24 * time.Hour)}
@@ -178,36 +246,36 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 		It("should select oldest nodes", func() {
 			strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 				Type:  "oldest",
-				Count: 2,
+				Count: ptr.To(intstr.FromInt32(2)),
 			}
 
-			selected, err := handler.SelectNodes(ctx, nodes, strategy)
+			selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(selected).To(HaveLen(2))
-			Expect(selected[0].Name).To(Equal("node-old"))
-			Expect(selected[1].Name).To(Equal("node-middle"))
+			Expect(selection.Nodes).To(HaveLen(2))
+			Expect(selection.Nodes[0].Name).To(Equal("node-old"))
+			Expect(selection.Nodes[1].Name).To(Equal("node-middle"))
 		})
 
 		It("should select newest nodes", func() {
 			strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 				Type:  "newest",
-				Count: 2,
+				Count: ptr.To(intstr.FromInt32(2)),
 			}
 
-			selected, err := handler.SelectNodes(ctx, nodes, strategy)
+			selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(selected).To(HaveLen(2))
-			Expect(selected[0].Name).To(Equal("node-new"))
-			Expect(selected[1].Name).To(Equal("node-middle"))
+			Expect(selection.Nodes).To(HaveLen(2))
+			Expect(selection.Nodes[0].Name).To(Equal("node-new"))
+			Expect(selection.Nodes[1].Name).To(Equal("node-middle"))
 		})
 
 		It("should return error for unsupported strategy", func() {
 			strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 				Type:  "unsupported",
-				Count: 1,
+				Count: ptr.To(intstr.FromInt32(1)),
 			}
 
-			_, err := handler.SelectNodes(ctx, nodes, strategy)
+			_, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("unsupported strategy type"))
 		})
@@ -215,23 +283,382 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 		It("should handle empty node list", func() {
 			strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 				Type:  "oldest",
-				Count: 1,
+				Count: ptr.To(intstr.FromInt32(1)),
 			}
 
-			selected, err := handler.SelectNodes(ctx, []corev1.Node{}, strategy)
+			selection, err := handler.SelectNodes(ctx, []corev1.Node{}, policyWith(strategy), nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(selected).To(BeEmpty())
+			Expect(selection.Nodes).To(BeEmpty())
 		})
 
 		It("should limit selection to available nodes", func() {
 			strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 				Type:  "oldest",
-				Count: 5,
+				Count: ptr.To(intstr.FromInt32(5)),
 			}
 
-			selected, err := handler.SelectNodes(ctx, nodes, strategy)
+			selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(selected).To(HaveLen(3))
+			Expect(selection.Nodes).To(HaveLen(3))
+			Expect(selection.DesiredCount).To(Equal(int32(5)))
+			Expect(selection.EligibleCount).To(Equal(int32(3)))
+		})
+
+		Context("when count is a percentage", func() {
+			It("should round up by default", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "oldest",
+					Count: ptr.To(intstr.FromString("50%")),
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.DesiredCount).To(Equal(int32(2)))
+				Expect(selection.Nodes).To(HaveLen(2))
+				Expect(selection.Nodes[0].Name).To(Equal("node-old"))
+				Expect(selection.Nodes[1].Name).To(Equal("node-middle"))
+			})
+
+			It("should round down when requested", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:     "oldest",
+					Count:    ptr.To(intstr.FromString("50%")),
+					Rounding: "down",
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.DesiredCount).To(Equal(int32(1)))
+				Expect(selection.Nodes).To(HaveLen(1))
+				Expect(selection.Nodes[0].Name).To(Equal("node-old"))
+			})
+
+			It("should apply the minimum bound", func() {
+				minCount := int32(2)
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:     "oldest",
+					Count:    ptr.To(intstr.FromString("10%")),
+					Rounding: "down",
+					MinCount: &minCount,
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.DesiredCount).To(Equal(int32(2)))
+				Expect(selection.Nodes).To(HaveLen(2))
+			})
+
+			It("should apply the maximum bound", func() {
+				maxCount := int32(1)
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:     "newest",
+					Count:    ptr.To(intstr.FromString("100%")),
+					MaxCount: &maxCount,
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.DesiredCount).To(Equal(int32(1)))
+				Expect(selection.Nodes).To(HaveLen(1))
+				Expect(selection.Nodes[0].Name).To(Equal("node-new"))
+			})
+
+			It("should resolve against Ready nodes only", func() {
+				notReadyNode := corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-not-ready"},
+					Status: corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{
+							{
+								Type:   corev1.NodeReady,
+								Status: corev1.ConditionFalse,
+							},
+						},
+					},
+				}
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:     "oldest",
+					Count:    ptr.To(intstr.FromString("34%")),
+					Rounding: "down",
+				}
+
+				selection, err := handler.SelectNodes(ctx, append(nodes, notReadyNode), policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.EligibleCount).To(Equal(int32(3)))
+				Expect(selection.DesiredCount).To(Equal(int32(1)))
+			})
+
+			It("should return error for an invalid count", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "oldest",
+					Count: ptr.To(intstr.FromString("half")),
+				}
+
+				_, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid strategy count"))
+			})
+
+			It("should return error for a zero percentage", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "oldest",
+					Count: ptr.To(intstr.FromString("0%")),
+				}
+
+				_, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("must be greater than 0"))
+			})
+
+			It("should return error for an unsupported rounding mode", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:     "oldest",
+					Count:    ptr.To(intstr.FromString("50%")),
+					Rounding: "nearest",
+				}
+
+				_, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unsupported rounding mode"))
+			})
+		})
+
+		Context("when using the spread strategy", func() {
+			var zonedNodes []corev1.Node
+
+			newZonedNode := func(name, zone string, age time.Duration) corev1.Node {
+				node := corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						CreationTimestamp: metav1.Time{Time: time.Now().Add(-age)},
+						Labels:            map[string]string{},
+					},
+					Status: corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{
+							{
+								Type:   corev1.NodeReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				}
+				if zone != "" {
+					node.Labels[constants.DefaultTopologyKey] = zone
+				}
+				return node
+			}
+
+			BeforeEach(func() {
+				// zone-a has three nodes, zone-b one and zone-c two
+				zonedNodes = []corev1.Node{
+					newZonedNode("a-new", "zone-a", 1*time.Hour),
+					newZonedNode("a-old", "zone-a", 30*time.Hour),
+					newZonedNode("a-middle", "zone-a", 10*time.Hour),
+					newZonedNode("b-only", "zone-b", 5*time.Hour),
+					newZonedNode("c-new", "zone-c", 2*time.Hour),
+					newZonedNode("c-old", "zone-c", 20*time.Hour),
+				}
+			})
+
+			It("should spread selected nodes evenly across uneven zones", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "spread",
+					Count: ptr.To(intstr.FromInt32(4)),
+				}
+
+				selection, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-old", "b-only", "c-old", "a-middle"}))
+			})
+
+			It("should keep taking from larger zones once smaller zones are exhausted", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "spread",
+					Count: ptr.To(intstr.FromInt32(6)),
+				}
+
+				selection, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-old", "b-only", "c-old", "a-middle", "c-new", "a-new"}))
+			})
+
+			It("should order nodes within a zone by the newest tie-breaker", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "spread",
+					Count:      ptr.To(intstr.FromInt32(3)),
+					TieBreaker: "newest",
+				}
+
+				selection, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-new", "b-only", "c-new"}))
+			})
+
+			It("should use a custom topology key", func() {
+				for i := range zonedNodes {
+					zonedNodes[i].Labels["rack"] = "rack-1"
+				}
+				zonedNodes[0].Labels["rack"] = "rack-2"
+
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:        "spread",
+					Count:       ptr.To(intstr.FromInt32(2)),
+					TopologyKey: "rack",
+				}
+
+				selection, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-old", "a-new"}))
+			})
+
+			It("should select nodes without the topology label only once the zones are exhausted", func() {
+				unzoned := newZonedNode("unzoned", "", 100*time.Hour)
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "spread",
+					Count: ptr.To(intstr.FromInt32(4)),
+				}
+
+				selection, err := handler.SelectNodes(ctx, append(zonedNodes, unzoned), policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-old", "b-only", "c-old", "a-middle"}))
+
+				strategy.Count = ptr.To(intstr.FromInt32(2))
+				selection, err = handler.SelectNodes(ctx, []corev1.Node{zonedNodes[3], unzoned}, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"b-only", "unzoned"}))
+			})
+
+			It("should keep current nodes within their zone when sticky", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "spread",
+					Count:      ptr.To(intstr.FromInt32(3)),
+					Stickiness: "sticky",
+				}
+
+				selection, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), []string{"a-new", "a-middle", "c-new"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-middle", "c-new", "a-new"}))
+			})
+
+			It("should keep all current nodes when sticky even if their zones are uneven", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "spread",
+					Count:      ptr.To(intstr.FromInt32(2)),
+					Stickiness: "sticky",
+				}
+				nodes := []corev1.Node{
+					newZonedNode("a1", "zone-a", 3*time.Hour),
+					newZonedNode("a2", "zone-a", 2*time.Hour),
+					newZonedNode("b1", "zone-b", time.Hour),
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"a1", "a2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a1", "a2"}))
+
+				strategy.Count = ptr.To(intstr.FromInt32(3))
+				selection, err = handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"a1", "a2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a1", "a2", "b1"}))
+			})
+
+			It("should fill the zones holding the fewest current nodes first", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "spread",
+					Count:      ptr.To(intstr.FromInt32(4)),
+					Stickiness: "sticky",
+				}
+
+				selection, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), []string{"a-new", "a-middle"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"a-middle", "a-new", "b-only", "c-old"}))
+			})
+
+			It("should return error for an unsupported tie-breaker", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "spread",
+					Count:      ptr.To(intstr.FromInt32(1)),
+					TieBreaker: "random",
+				}
+
+				_, err := handler.SelectNodes(ctx, zonedNodes, policyWith(strategy), nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unsupported tie-breaker"))
+			})
+		})
+
+		Context("when the strategy is sticky", func() {
+			It("should keep current nodes with the random strategy", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "random",
+					Count:      ptr.To(intstr.FromInt32(2)),
+					Stickiness: "sticky",
+				}
+
+				for i := 0; i < 10; i++ {
+					selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"node-new", "node-old"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(selection.NodeNames()).To(ConsistOf("node-new", "node-old"))
+				}
+			})
+
+			It("should keep a current node even if the strategy prefers another", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "oldest",
+					Count:      ptr.To(intstr.FromInt32(1)),
+					Stickiness: "sticky",
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"node-new"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"node-new"}))
+			})
+
+			It("should fill the shortfall using the strategy", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "oldest",
+					Count:      ptr.To(intstr.FromInt32(2)),
+					Stickiness: "sticky",
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"node-new"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"node-new", "node-old"}))
+			})
+
+			It("should drop current nodes that are no longer eligible", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "oldest",
+					Count:      ptr.To(intstr.FromInt32(1)),
+					Stickiness: "sticky",
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"node-gone"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"node-old"}))
+			})
+
+			It("should ignore current nodes when not sticky", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "oldest",
+					Count: ptr.To(intstr.FromInt32(1)),
+				}
+
+				selection, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), []string{"node-new"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.NodeNames()).To(Equal([]string{"node-old"}))
+			})
+
+			It("should return error for an unsupported stickiness", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:       "oldest",
+					Count:      ptr.To(intstr.FromInt32(1)),
+					Stickiness: "always",
+				}
+
+				_, err := handler.SelectNodes(ctx, nodes, policyWith(strategy), nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("unsupported stickiness"))
+			})
 		})
 
 		Context("when dealing with NotReady nodes", func() {
@@ -305,16 +732,16 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 			It("should filter out NotReady nodes and select only Ready nodes", func() {
 				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 					Type:  "oldest",
-					Count: 2,
+					Count: ptr.To(intstr.FromInt32(2)),
 				}
 
-				selected, err := handler.SelectNodes(ctx, mixedNodes, strategy)
+				selection, err := handler.SelectNodes(ctx, mixedNodes, policyWith(strategy), nil)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(selected).To(HaveLen(2))
+				Expect(selection.Nodes).To(HaveLen(2))
 
 				// Should only include ready nodes, and select oldest first
-				Expect(selected[0].Name).To(Equal("ready-old"))
-				Expect(selected[1].Name).To(Equal("ready-new"))
+				Expect(selection.Nodes[0].Name).To(Equal("ready-old"))
+				Expect(selection.Nodes[1].Name).To(Equal("ready-new"))
 			})
 
 			It("should return empty when no nodes are ready", func() {
@@ -345,26 +772,99 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 
 				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 					Type:  "oldest",
-					Count: 1,
+					Count: ptr.To(intstr.FromInt32(1)),
+				}
+
+				selection, err := handler.SelectNodes(ctx, notReadyNodes, policyWith(strategy), nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selection.Nodes).To(BeEmpty())
+			})
+
+			It("should apply the eligibility filters and report the excluded nodes", func() {
+				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "oldest",
+					Count: ptr.To(intstr.FromInt32(2)),
 				}
+				cordoned := mixedNodes[0].DeepCopy()
+				cordoned.Name = "cordoned"
+				cordoned.Spec.Unschedulable = true
+
+				policy := policyWith(strategy)
+				policy.Spec.Eligibility = &nlpv1alpha1.NodeEligibility{ExcludeUnschedulable: true}
 
-				selected, err := handler.SelectNodes(ctx, notReadyNodes, strategy)
+				selection, err := handler.SelectNodes(ctx, append(mixedNodes, *cordoned), policy, nil)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(selected).To(BeEmpty())
+				Expect(selection.NodeNames()).NotTo(ContainElement("cordoned"))
+				Expect(selection.Exclusions.Unschedulable).To(Equal(int32(1)))
+				Expect(selection.Exclusions.NotReady).To(BeNumerically(">", 0))
+				Expect(selection.EligibleCount).To(Equal(int32(2)))
+			})
+
+			Context("with a NotReady toleration", func() {
+				var (
+					policy   *nlpv1alpha1.NodeLabelPolicy
+					notReady corev1.Node
+				)
+
+				BeforeEach(func() {
+					policy = policyWith(nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:       "oldest",
+						Count:      ptr.To(intstr.FromInt32(1)),
+						Stickiness: "sticky",
+					})
+					policy.Spec.NotReadyToleration = &metav1.Duration{Duration: 5 * time.Minute}
+
+					notReady = corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{Name: "flapping"},
+						Status: corev1.NodeStatus{
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionFalse,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+								},
+							},
+						},
+					}
+				})
+
+				It("should keep a selected node that became NotReady within the toleration", func() {
+					selection, err := handler.SelectNodes(ctx, append(mixedNodes, notReady), policy, []string{"flapping"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(selection.NodeNames()).To(Equal([]string{"flapping"}))
+					Expect(selection.ToleratedUntil).NotTo(BeNil())
+					Expect(*selection.ToleratedUntil).To(BeTemporally("~", time.Now().Add(4*time.Minute), time.Second))
+				})
+
+				It("should deselect a node that has been NotReady for longer than the toleration", func() {
+					notReady.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+					selection, err := handler.SelectNodes(ctx, append(mixedNodes, notReady), policy, []string{"flapping"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(selection.NodeNames()).To(Equal([]string{"ready-old"}))
+					Expect(selection.ToleratedUntil).To(BeNil())
+				})
+
+				It("should not select a NotReady node that is not selected yet", func() {
+					selection, err := handler.SelectNodes(ctx, append(mixedNodes, notReady), policy, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(selection.NodeNames()).To(Equal([]string{"ready-old"}))
+					Expect(selection.ToleratedUntil).To(BeNil())
+				})
 			})
 
 			It("should work with newest strategy filtering NotReady nodes", func() {
 				strategy := nlpv1alpha1.NodeLabelPolicyStrategy{
 					Type:  "newest",
-					Count: 1,
+					Count: ptr.To(intstr.FromInt32(1)),
 				}
 
-				selected, err := handler.SelectNodes(ctx, mixedNodes, strategy)
+				selection, err := handler.SelectNodes(ctx, mixedNodes, policyWith(strategy), nil)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(selected).To(HaveLen(1))
+				Expect(selection.Nodes).To(HaveLen(1))
 
 				// Should select the newest ready node
-				Expect(selected[0].Name).To(Equal("ready-new"))
+				Expect(selection.Nodes[0].Name).To(Equal("ready-new"))
 			})
 		})
 	})
@@ -388,7 +888,7 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 
 		It("should apply labels to node", func() {
 			managedByLabelKey := fmt.Sprintf("%s.test/managed-by", constants.ManagedByLabelPrefix)
-			err := handler.ApplyLabelsToNode(ctx, node, labels, managedByLabelKey)
+			_, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(node.Labels["environment"]).To(Equal("production"))
@@ -398,35 +898,876 @@ var _ = Describe("NodeLabelPolicyHandler", func() {
 
 		It("should handle nil labels map", func() {
 			node.Labels = nil
-			managedByLabelKey := fmt.Sprintf("%s.test/managed-by", constants.ManagedByLabelPrefix)
-			err := handler.ApplyLabelsToNode(ctx, node, labels, managedByLabelKey)
+			_, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(node.Labels).NotTo(BeNil())
 			Expect(node.Labels["environment"]).To(Equal("production"))
 		})
-	})
 
-	Describe("CleanupLabelsFromAllNodes", func() {
-		It("should handle nil policyLabels gracefully", func() {
-			// nil policyLabels should only remove managed-by and prefix labels
-			err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", nil)
-			Expect(err).NotTo(HaveOccurred())
-		})
+		Context("when applying labels with server-side apply", func() {
+			var fakeClient *k8sfakes.FakeClient
+			managedByLabelKey := fmt.Sprintf("%s.test/managed-by", constants.ManagedByLabelPrefix)
 
-		It("should handle empty policyLabels map", func() {
-			// empty map should work the same as nil
-			err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", map[string]string{})
-			Expect(err).NotTo(HaveOccurred())
-		})
+			BeforeEach(func() {
+				fakeClient = &k8sfakes.FakeClient{}
+				handler = NewNodeLabelPolicyHandler(fakeClient)
+			})
 
-		It("should handle non-empty policyLabels map", func() {
-			policyLabels := map[string]string{
-				"environment": "production",
-				"workload":    "monitoring",
-			}
-			err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", policyLabels)
-			Expect(err).NotTo(HaveOccurred())
+			It("should apply only the policy's labels with the policy's field manager", func() {
+				node.Labels["unrelated"] = "value"
+
+				result, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Changed).To(BeTrue())
+				Expect(result.Labeled).To(BeTrue())
+				Expect(result.Conflicts).To(BeEmpty())
+
+				Expect(fakeClient.UpdateCallCount()).To(Equal(0))
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+				_, obj, patch, opts := fakeClient.PatchArgsForCall(0)
+				Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+				data, err := patch.Data(obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(MatchJSON(fmt.Sprintf(
+					`{"apiVersion":"v1","kind":"Node","metadata":{"name":"test-node","labels":{"environment":"production","workload":"monitoring","%s":"true"},"annotations":{"nlp.test/labels":%q}}}`,
+					managedByLabelKey, `{"environment":null,"workload":null}`)))
+
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				Expect(patchOpts.FieldManager).To(Equal("nlp/test"))
+				Expect(patchOpts.Force).To(BeNil())
+			})
+
+			It("should not patch a node on which the policy already owns the labels", func() {
+				node.Labels["environment"] = "production"
+				node.Labels["workload"] = "monitoring"
+				node.Labels[managedByLabelKey] = "true"
+				node.Annotations = map[string]string{"nlp.test/labels": `{"environment":null,"workload":null}`}
+				node.ManagedFields = []metav1.ManagedFieldsEntry{
+					{
+						Manager:   "nlp/test",
+						Operation: metav1.ManagedFieldsOperationApply,
+						FieldsV1: &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(
+							`{"f:metadata":{"f:annotations":{"f:nlp.test/labels":{}},"f:labels":{"f:environment":{},"f:workload":{},"f:%s":{}}}}`, managedByLabelKey))},
+					},
+				}
+
+				result, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Changed).To(BeFalse())
+
+				Expect(fakeClient.PatchCallCount()).To(Equal(0))
+				Expect(fakeClient.UpdateCallCount()).To(Equal(0))
+			})
+
+			It("should apply when the policy owns labels that are no longer desired", func() {
+				node.Labels["environment"] = "production"
+				node.Labels["workload"] = "monitoring"
+				node.Labels["stale"] = "value"
+				node.Labels[managedByLabelKey] = "true"
+				node.ManagedFields = []metav1.ManagedFieldsEntry{
+					{
+						Manager:   "nlp/test",
+						Operation: metav1.ManagedFieldsOperationApply,
+						FieldsV1: &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(
+							`{"f:metadata":{"f:labels":{"f:environment":{},"f:workload":{},"f:stale":{},"f:%s":{}}}}`, managedByLabelKey))},
+					},
+				}
+
+				_, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			})
+
+			It("should not report a node already carrying the policy's labels as labeled when updating it", func() {
+				node.Labels["environment"] = "staging"
+				node.Labels["workload"] = "monitoring"
+				node.Labels[managedByLabelKey] = "true"
+
+				result, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Changed).To(BeTrue())
+				Expect(result.Labeled).To(BeFalse())
+				Expect(node.Labels["environment"]).To(Equal("production"))
+			})
+
+			It("should report labels owned by another field manager and take them over", func() {
+				fakeClient.PatchReturnsOnCall(0, apierrors.NewApplyConflict([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Message: `conflict with "kubectl-label" using v1`,
+						Field:   ".metadata.labels.environment",
+					},
+				}, "Apply failed with 1 conflict"))
+
+				result, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Conflicts).To(ConsistOf(`.metadata.labels.environment (conflict with "kubectl-label" using v1)`))
+
+				Expect(fakeClient.PatchCallCount()).To(Equal(2))
+				_, _, _, opts := fakeClient.PatchArgsForCall(1)
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				Expect(patchOpts.FieldManager).To(Equal("nlp/test"))
+				Expect(patchOpts.Force).To(HaveValue(BeTrue()))
+			})
+
+			It("should take over labels owned by a lower-priority policy without reporting them", func() {
+				fakeClient.PatchReturnsOnCall(0, apierrors.NewApplyConflict([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Message: `conflict with "nlp/low" using v1`,
+						Field:   ".metadata.labels.environment",
+					},
+				}, "Apply failed with 1 conflict"))
+
+				result, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Changed).To(BeTrue())
+				Expect(result.Conflicts).To(BeEmpty())
+
+				Expect(fakeClient.PatchCallCount()).To(Equal(2))
+				_, _, _, opts := fakeClient.PatchArgsForCall(1)
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				Expect(patchOpts.Force).To(HaveValue(BeTrue()))
+			})
+
+			It("should apply the policy's annotations alongside the labels", func() {
+				annotations := map[string]string{"example.com/owner": "platform"}
+
+				_, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, annotations)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(node.Annotations).To(HaveKeyWithValue("example.com/owner", "platform"))
+
+				_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+				data, err := patch.Data(obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(MatchJSON(fmt.Sprintf(
+					`{"apiVersion":"v1","kind":"Node","metadata":{"name":"test-node","labels":{"environment":"production","workload":"monitoring","%s":"true"},"annotations":{"example.com/owner":"platform","nlp.test/labels":%q}}}`,
+					managedByLabelKey, `{"environment":null,"workload":null}`)))
+			})
+
+			It("should leave alone annotations the node already carries without the policy owning them", func() {
+				node.Annotations = map[string]string{"example.com/owner": "someone-else"}
+				annotations := map[string]string{"example.com/owner": "platform", "example.com/team": "infra"}
+
+				_, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, annotations)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(node.Annotations).To(HaveKeyWithValue("example.com/owner", "someone-else"))
+				Expect(node.Annotations).To(HaveKeyWithValue("example.com/team", "infra"))
+
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				Expect(obj.GetAnnotations()).To(HaveKey("example.com/team"))
+				Expect(obj.GetAnnotations()).NotTo(HaveKey("example.com/owner"))
+			})
+
+			It("should not patch a node on which the policy already owns the labels and annotations", func() {
+				node.Labels["environment"] = "production"
+				node.Labels["workload"] = "monitoring"
+				node.Labels[managedByLabelKey] = "true"
+				node.Annotations = map[string]string{
+					"example.com/owner": "platform",
+					"nlp.test/labels":   `{"environment":null,"workload":null}`,
+				}
+				node.ManagedFields = []metav1.ManagedFieldsEntry{
+					{
+						Manager:   "nlp/test",
+						Operation: metav1.ManagedFieldsOperationApply,
+						FieldsV1: &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(
+							`{"f:metadata":{"f:annotations":{"f:example.com/owner":{},"f:nlp.test/labels":{}},"f:labels":{"f:environment":{},"f:workload":{},"f:%s":{}}}}`, managedByLabelKey))},
+					},
+				}
+
+				result, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, map[string]string{"example.com/owner": "platform"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Changed).To(BeFalse())
+				Expect(fakeClient.PatchCallCount()).To(Equal(0))
+
+				// dropping the annotation from the policy releases it
+				_, err = handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			})
+
+			It("should return an error when the patch fails", func() {
+				fakeClient.PatchReturns(fmt.Errorf("connection refused"))
+
+				_, err := handler.ApplyLabelsToNode(ctx, node, "test", labels, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to update node test-node"))
+
+				var nodeErr *NodeUpdateError
+				Expect(errors.As(err, &nodeErr)).To(BeTrue())
+				Expect(nodeErr.Node.Name).To(Equal("test-node"))
+			})
+		})
+	})
+
+	Describe("PlanLabelChanges", func() {
+		var policy *nlpv1alpha1.NodeLabelPolicy
+		managedByLabelKey := fmt.Sprintf("%s.test/managed-by", constants.ManagedByLabelPrefix)
+
+		newNode := func(name string, labels map[string]string) corev1.Node {
+			return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		}
+
+		BeforeEach(func() {
+			policy = &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Labels: map[string]string{"environment": "production", "workload": "monitoring"},
+				},
+			}
+		})
+
+		It("should plan the policy's labels for selected nodes that miss them", func() {
+			selected := newNode("selected", nil)
+
+			plan, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{selected}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(1))
+			Expect(plan.Apply[0].Node.Name).To(Equal("selected"))
+			Expect(plan.Apply[0].Labels).To(Equal(policy.Spec.Labels))
+			Expect(plan.Remove).To(BeEmpty())
+		})
+
+		It("should leave out selected nodes on which the policy already owns the labels", func() {
+			owned := newNode("owned", map[string]string{
+				"environment":     "production",
+				"workload":        "monitoring",
+				managedByLabelKey: "true",
+			})
+			owned.Annotations = map[string]string{"nlp.test/labels": `{"environment":null,"workload":null}`}
+			owned.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "nlp/test",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(
+						`{"f:metadata":{"f:annotations":{"f:nlp.test/labels":{}},"f:labels":{"f:environment":{},"f:workload":{},"f:%s":{}}}}`, managedByLabelKey))},
+				},
+			}
+
+			plan, err := handler.PlanLabelChanges(policy, []corev1.Node{owned}, []corev1.Node{owned}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(BeEmpty())
+			Expect(plan.Remove).To(BeEmpty())
+		})
+
+		It("should plan the labels without the keys left to higher-priority policies", func() {
+			selected := newNode("selected", nil)
+			conflicts := PolicyConflicts{{NodeName: "selected", Key: "environment", Winner: "important-policy"}}
+
+			plan, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{selected}, conflicts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(1))
+			Expect(plan.Apply[0].Labels).To(Equal(map[string]string{"workload": "monitoring"}))
+		})
+
+		It("should plan selected nodes that miss the policy's annotations", func() {
+			policy.Spec.Annotations = map[string]string{"example.com/owner": "platform"}
+			owned := newNode("owned", map[string]string{
+				"environment":     "production",
+				"workload":        "monitoring",
+				managedByLabelKey: "true",
+			})
+			owned.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "nlp/test",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(fmt.Sprintf(
+						`{"f:metadata":{"f:labels":{"f:environment":{},"f:workload":{},"f:%s":{}}}}`, managedByLabelKey))},
+				},
+			}
+
+			plan, err := handler.PlanLabelChanges(policy, []corev1.Node{owned}, []corev1.Node{owned}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(1))
+			Expect(plan.Apply[0].Annotations).To(Equal(policy.Spec.Annotations))
+		})
+
+		It("should render templated labels per node with the node's index among the selected nodes", func() {
+			policy.Spec.Labels = map[string]string{
+				"shard": "{{ .Index }}",
+				"zone":  `{{ index .Node.Labels "topology.kubernetes.io/zone" }}`,
+			}
+			nodeB := newNode("node-b", map[string]string{"topology.kubernetes.io/zone": "zone-b"})
+			nodeA := newNode("node-a", map[string]string{"topology.kubernetes.io/zone": "zone-a"})
+
+			plan, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{nodeB, nodeA}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(2))
+			Expect(plan.Apply[0].Node.Name).To(Equal("node-b"))
+			Expect(plan.Apply[0].Labels).To(Equal(map[string]string{"shard": "0", "zone": "zone-b"}))
+			Expect(plan.Apply[0].Annotations).To(HaveKeyWithValue("nlp.test/index", "0"))
+			Expect(plan.Apply[1].Labels).To(Equal(map[string]string{"shard": "1", "zone": "zone-a"}))
+			Expect(plan.Apply[1].Annotations).To(HaveKeyWithValue("nlp.test/index", "1"))
+		})
+
+		It("should keep the index of the nodes that stay selected", func() {
+			policy.Spec.Labels = map[string]string{"shard": "{{ .Index }}"}
+			labeled := func(name, index string) corev1.Node {
+				node := newNode(name, map[string]string{managedByLabelKey: "true"})
+				node.Annotations = map[string]string{"nlp.test/index": index}
+				return node
+			}
+			nodeA, nodeB, nodeC := labeled("node-a", "0"), labeled("node-b", "1"), labeled("node-c", "2")
+			nodeD := newNode("node-d", nil)
+
+			plan, err := handler.PlanLabelChanges(policy, []corev1.Node{nodeA, nodeB, nodeC}, []corev1.Node{nodeD, nodeC, nodeB}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			shards := map[string]string{}
+			for _, planned := range plan.Apply {
+				shards[planned.Node.Name] = planned.Labels["shard"]
+			}
+			Expect(shards).To(Equal(map[string]string{"node-b": "1", "node-c": "2", "node-d": "0"}))
+		})
+
+		It("should renumber indexes beyond the selection when it shrinks", func() {
+			policy.Spec.Labels = map[string]string{"shard": "{{ .Index }}"}
+			labeled := func(name, index string) corev1.Node {
+				node := newNode(name, map[string]string{managedByLabelKey: "true"})
+				node.Annotations = map[string]string{"nlp.test/index": index}
+				return node
+			}
+			nodeA, nodeB, nodeC := labeled("node-a", "0"), labeled("node-b", "1"), labeled("node-c", "2")
+
+			plan, err := handler.PlanLabelChanges(policy, []corev1.Node{nodeA, nodeB, nodeC}, []corev1.Node{nodeB, nodeC}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			shards := map[string]string{}
+			for _, planned := range plan.Apply {
+				shards[planned.Node.Name] = planned.Labels["shard"]
+			}
+			Expect(shards).To(Equal(map[string]string{"node-b": "1", "node-c": "0"}))
+		})
+
+		It("should not record an index without templated labels", func() {
+			plan, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{newNode("node-a", nil)}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply[0].Annotations).NotTo(HaveKey("nlp.test/index"))
+		})
+
+		It("should return an error when a templated label can not be rendered", func() {
+			policy.Spec.Labels = map[string]string{"shard": "shard {{ .Index }}"}
+
+			_, err := handler.PlanLabelChanges(policy, nil, []corev1.Node{newNode("node-a", nil)}, nil)
+			Expect(err).To(MatchError(ContainSubstring(`label shard rendered to "shard 0" for node node-a`)))
+		})
+
+		It("should plan the removal of labels from managed nodes that are not selected", func() {
+			selected := newNode("selected", map[string]string{managedByLabelKey: "true"})
+			unselected := newNode("unselected", map[string]string{managedByLabelKey: "true"})
+			unmanaged := newNode("unmanaged", map[string]string{"environment": "production"})
+
+			plan, err := handler.PlanLabelChanges(policy, []corev1.Node{selected, unselected, unmanaged}, []corev1.Node{selected}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.NodesToLabel()).To(Equal([]string{"selected"}))
+			Expect(plan.NodesToUnlabel()).To(Equal([]string{"unselected"}))
+		})
+	})
+
+	Describe("RemoveLabelsFromNodes", func() {
+		var fakeClient *k8sfakes.FakeClient
+		managedByLabelKey := fmt.Sprintf("%s.test/managed-by", constants.ManagedByLabelPrefix)
+		policyLabels := map[string]string{"environment": "production"}
+
+		newLabeledNode := func(name string) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						"environment":     "production",
+						"unrelated":       "value",
+						managedByLabelKey: "true",
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			fakeClient = &k8sfakes.FakeClient{}
+			handler = NewNodeLabelPolicyHandler(fakeClient)
+		})
+
+		It("should apply an empty label set to the given nodes", func() {
+			unselected := newLabeledNode("unselected")
+
+			released, err := handler.RemoveLabelsFromNodes(ctx, []corev1.Node{unselected}, "test", policyLabels)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(HaveLen(1))
+			Expect(released[0].Name).To(Equal("unselected"))
+
+			Expect(fakeClient.UpdateCallCount()).To(Equal(0))
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, obj, patch, opts := fakeClient.PatchArgsForCall(0)
+			Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"unselected"}}`))
+
+			patchOpts := &client.PatchOptions{}
+			patchOpts.ApplyOptions(opts)
+			Expect(patchOpts.FieldManager).To(Equal("nlp/test"))
+		})
+
+		It("should remove labels by key from nodes labeled before ownership was tracked", func() {
+			unselected := newLabeledNode("unselected")
+			fakeClient.PatchStub = func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+				if patch.Type() == types.ApplyPatchType {
+					// the labels are still owned by the manager that set them before server-side apply was used
+					obj.SetLabels(unselected.Labels)
+				}
+				return nil
+			}
+
+			_, err := handler.RemoveLabelsFromNodes(ctx, []corev1.Node{unselected}, "test", policyLabels)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(2))
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(1)
+			Expect(patch.Type()).To(Equal(types.MergePatchType))
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(fmt.Sprintf(
+				`{"metadata":{"labels":{"environment":null,"%s":null}}}`, managedByLabelKey)))
+		})
+
+		It("should not patch nodes that are not managed by the policy", func() {
+			node := newLabeledNode("unmanaged")
+			delete(node.Labels, managedByLabelKey)
+
+			released, err := handler.RemoveLabelsFromNodes(ctx, []corev1.Node{node}, "test", policyLabels)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(BeEmpty())
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(0))
+		})
+
+		It("should return the released nodes and the failing node on error", func() {
+			fakeClient.PatchReturnsOnCall(1, fmt.Errorf("connection refused"))
+
+			released, err := handler.RemoveLabelsFromNodes(ctx, []corev1.Node{newLabeledNode("first"), newLabeledNode("second")}, "test", policyLabels)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to remove labels from node second"))
+			Expect(released).To(HaveLen(1))
+			Expect(released[0].Name).To(Equal("first"))
+
+			var nodeErr *NodeUpdateError
+			Expect(errors.As(err, &nodeErr)).To(BeTrue())
+			Expect(nodeErr.Node.Name).To(Equal("second"))
+		})
+	})
+
+	Describe("CleanupLabelsFromAllNodes", func() {
+		It("should handle nil policyLabels gracefully", func() {
+			// nil policyLabels should only remove managed-by and prefix labels
+			_, err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should handle empty policyLabels map", func() {
+			// empty map should work the same as nil
+			_, err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should handle non-empty policyLabels map", func() {
+			policyLabels := map[string]string{
+				"environment": "production",
+				"workload":    "monitoring",
+			}
+			_, err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", policyLabels)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("with managed nodes", func() {
+			var fakeClient *k8sfakes.FakeClient
+			var managedLabels map[string]string
+
+			BeforeEach(func() {
+				managedLabels = map[string]string{
+					"environment":                "production",
+					"nlp.test-policy/managed-by": "true",
+					"nlp.test-policy/extra":      "value",
+					"kubernetes.io/hostname":     "managed",
+				}
+
+				fakeClient = &k8sfakes.FakeClient{}
+				fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					list.(*corev1.NodeList).Items = []corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   "managed",
+								Labels: managedLabels,
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   "unmanaged",
+								Labels: map[string]string{"environment": "production"},
+							},
+						},
+					}
+					return nil
+				}
+				handler = NewNodeLabelPolicyHandler(fakeClient)
+			})
+
+			It("should release the policy's labels on managed nodes", func() {
+				released, err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", map[string]string{"environment": "production"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(released).To(HaveLen(1))
+				Expect(released[0].Name).To(Equal("managed"))
+
+				Expect(fakeClient.UpdateCallCount()).To(Equal(0))
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+				_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+				Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+				Expect(obj.GetName()).To(Equal("managed"))
+			})
+
+			It("should remove labels by key from nodes labeled before ownership was tracked", func() {
+				fakeClient.PatchStub = func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+					if patch.Type() == types.ApplyPatchType {
+						obj.SetLabels(managedLabels)
+					}
+					return nil
+				}
+
+				_, err := handler.CleanupLabelsFromAllNodes(ctx, "test-policy", map[string]string{"environment": "production"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.PatchCallCount()).To(Equal(2))
+				_, obj, patch, _ := fakeClient.PatchArgsForCall(1)
+				Expect(obj.GetName()).To(Equal("managed"))
+				data, err := patch.Data(obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(MatchJSON(
+					`{"metadata":{"labels":{"environment":null,"nlp.test-policy/managed-by":null,"nlp.test-policy/extra":null}}}`))
+			})
+		})
+	})
+
+	Describe("OrphanLabelsOnAllNodes", func() {
+		var fakeClient *k8sfakes.FakeClient
+		var managedNode corev1.Node
+
+		BeforeEach(func() {
+			managedNode = corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "managed",
+					Labels: map[string]string{
+						"environment":                "production",
+						"nlp.test-policy/managed-by": "true",
+					},
+					ManagedFields: []metav1.ManagedFieldsEntry{
+						{Manager: "kubelet", Operation: metav1.ManagedFieldsOperationUpdate},
+						{Manager: "nlp/test-policy", Operation: metav1.ManagedFieldsOperationApply},
+					},
+				},
+			}
+
+			fakeClient = &k8sfakes.FakeClient{}
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*corev1.NodeList).Items = []corev1.Node{managedNode}
+				return nil
+			}
+			handler = NewNodeLabelPolicyHandler(fakeClient)
+		})
+
+		It("should remove the managed-by label and the policy's ownership but keep the labels", func() {
+			orphaned, err := handler.OrphanLabelsOnAllNodes(ctx, "test-policy")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphaned).To(HaveLen(1))
+			Expect(orphaned[0].Name).To(Equal("managed"))
+
+			_, list, opts := fakeClient.ListArgsForCall(0)
+			Expect(list).To(BeAssignableToTypeOf(&corev1.NodeList{}))
+			Expect(opts).To(ContainElement(client.HasLabels{"nlp.test-policy/managed-by"}))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+			Expect(patch.Type()).To(Equal(types.MergePatchType))
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(
+				`{"metadata":{"labels":{"nlp.test-policy/managed-by":null},"managedFields":[{"manager":"kubelet","operation":"Update"}]}}`))
+		})
+
+		It("should clear the managed fields when the policy was their only owner", func() {
+			managedNode.ManagedFields = managedNode.ManagedFields[1:]
+
+			_, err := handler.OrphanLabelsOnAllNodes(ctx, "test-policy")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(
+				`{"metadata":{"labels":{"nlp.test-policy/managed-by":null},"managedFields":[{}]}}`))
+		})
+
+		It("should return the orphaned nodes and the failing node on error", func() {
+			fakeClient.PatchReturns(fmt.Errorf("connection refused"))
+
+			orphaned, err := handler.OrphanLabelsOnAllNodes(ctx, "test-policy")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to orphan labels on node managed"))
+			Expect(orphaned).To(BeEmpty())
+		})
+	})
+
+	Describe("UpdatePolicyStatus", func() {
+		var policy *nlpv1alpha1.NodeLabelPolicy
+
+		newSelection := func(desired, eligible int32, names ...string) NodeSelection {
+			selection := NodeSelection{DesiredCount: desired, EligibleCount: eligible}
+			for _, name := range names {
+				selection.Nodes = append(selection.Nodes, corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+			return selection
+		}
+
+		BeforeEach(func() {
+			policy = &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-policy",
+					Generation: 3,
+				},
+			}
+		})
+
+		It("should report counts and a Ready condition when the desired count is met", func() {
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection: newSelection(2, 3, "node-a", "node-b"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-a", "node-b"}))
+			Expect(policy.Status.DesiredCount).To(Equal(int32(2)))
+			Expect(policy.Status.EligibleCount).To(Equal(int32(3)))
+			Expect(policy.Status.SelectedCount).To(Equal(int32(2)))
+			Expect(policy.Status.ObservedGeneration).To(Equal(int64(3)))
+			Expect(policy.Status.LastReconcileTime).NotTo(BeNil())
+
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionProgressing)).To(BeTrue())
+		})
+
+		It("should report the nodes excluded by each eligibility filter", func() {
+			selection := newSelection(2, 2, "node-a", "node-b")
+			selection.Exclusions = nlpv1alpha1.NodeExclusions{NotReady: 1, Tainted: 2}
+
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{Selection: selection})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policy.Status.ExcludedNodes).To(Equal(&nlpv1alpha1.NodeExclusions{NotReady: 1, Tainted: 2}))
+		})
+
+		It("should report Progressing as false when the selection is unchanged", func() {
+			policy.Status.SelectedNodes = []string{"node-b", "node-a"}
+
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection: newSelection(2, 2, "node-a", "node-b"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionProgressing)).To(BeTrue())
+		})
+
+		It("should report InsufficientEligibleNodes when fewer nodes than desired are selected", func() {
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection: newSelection(5, 2, "node-a", "node-b"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			ready := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(nlpv1alpha1.ReasonInsufficientEligibleNodes))
+
+			degraded := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal(nlpv1alpha1.ReasonInsufficientEligibleNodes))
+		})
+
+		It("should report LabelConflict when labels of another field manager were taken over", func() {
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection:        newSelection(1, 1, "node-a"),
+				ConflictingNodes: []string{"node-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionReady)).To(BeTrue())
+			degraded := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal(nlpv1alpha1.ReasonLabelConflict))
+			Expect(degraded.Message).To(ContainSubstring("node-a"))
+		})
+
+		It("should report PolicyConflict when label keys are left to higher-priority policies", func() {
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection: newSelection(1, 1, "node-a"),
+				PolicyConflicts: PolicyConflicts{
+					{NodeName: "node-a", Key: "environment", Winner: "important-policy"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionReady)).To(BeTrue())
+			degraded := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal(nlpv1alpha1.ReasonPolicyConflict))
+			Expect(degraded.Message).To(Equal("Label keys [environment] are left to higher-priority policies [important-policy] on nodes [node-a]"))
+		})
+
+		It("should report NodeUpdateFailed and keep the previous selection on failure", func() {
+			policy.Status.SelectedNodes = []string{"node-a"}
+
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection: newSelection(1, 2, "node-b"),
+				Err:       fmt.Errorf("failed to update node node-b"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-a"}))
+			degraded := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)
+			Expect(degraded).NotTo(BeNil())
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal(nlpv1alpha1.ReasonNodeUpdateFailed))
+			Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionReady)).To(BeTrue())
+		})
+
+		Context("when the policy is a dry run", func() {
+			BeforeEach(func() {
+				policy.Spec.DryRun = true
+				policy.Status.SelectedNodes = []string{"node-a"}
+				policy.Status.SelectedCount = 1
+			})
+
+			It("should report the plan and keep the nodes that carry the labels", func() {
+				err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+					Selection: newSelection(1, 2, "node-b"),
+					Plan: LabelPlan{
+						Apply:  []PlannedLabels{{Node: corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}}}},
+						Remove: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-a"}))
+				Expect(policy.Status.SelectedCount).To(Equal(int32(1)))
+				Expect(policy.Status.DesiredCount).To(Equal(int32(1)))
+				Expect(policy.Status.EligibleCount).To(Equal(int32(2)))
+				Expect(policy.Status.Plan).To(Equal(&nlpv1alpha1.NodeLabelPolicyPlan{
+					NodesToLabel:   []string{"node-b"},
+					NodesToUnlabel: []string{"node-a"},
+				}))
+
+				ready := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(nlpv1alpha1.ReasonDryRun))
+				Expect(ready.Message).To(Equal("Dry run plans to label nodes [node-b] and unlabel nodes [node-a]"))
+
+				progressing := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionProgressing)
+				Expect(progressing).NotTo(BeNil())
+				Expect(progressing.Reason).To(Equal(nlpv1alpha1.ReasonDryRun))
+				Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)).To(BeTrue())
+			})
+
+			It("should report InsufficientEligibleNodes for the planned selection", func() {
+				err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+					Selection: newSelection(3, 1, "node-a"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				degraded := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)
+				Expect(degraded).NotTo(BeNil())
+				Expect(degraded.Reason).To(Equal(nlpv1alpha1.ReasonInsufficientEligibleNodes))
+			})
+
+			It("should clear the plan once the policy is enforced", func() {
+				Expect(handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{Selection: newSelection(1, 1, "node-a")})).To(Succeed())
+				Expect(policy.Status.Plan).NotTo(BeNil())
+
+				policy.Spec.DryRun = false
+				Expect(handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{Selection: newSelection(1, 1, "node-a")})).To(Succeed())
+				Expect(policy.Status.Plan).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionReady)).To(BeTrue())
+			})
+		})
+
+		It("should report Suspended as false when the policy is reconciled", func() {
+			err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{
+				Selection: newSelection(1, 1, "node-a"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionSuspended)).To(BeTrue())
+		})
+
+		Context("when the policy is suspended", func() {
+			BeforeEach(func() {
+				policy.Spec.Suspend = true
+				policy.Status.SelectedNodes = []string{"node-a"}
+				policy.Status.DesiredCount = 1
+				policy.Status.SelectedCount = 1
+			})
+
+			It("should report Suspended and keep the selection", func() {
+				Expect(handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{})).To(Succeed())
+
+				Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-a"}))
+				Expect(policy.Status.SelectedCount).To(Equal(int32(1)))
+				Expect(policy.Status.DesiredCount).To(Equal(int32(1)))
+
+				suspended := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionSuspended)
+				Expect(suspended).NotTo(BeNil())
+				Expect(suspended.Status).To(Equal(metav1.ConditionTrue))
+				Expect(suspended.Reason).To(Equal(nlpv1alpha1.ReasonSuspended))
+				Expect(suspended.Message).To(ContainSubstring("labels are left on the nodes"))
+
+				ready := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(nlpv1alpha1.ReasonSuspended))
+				Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionProgressing)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)).To(BeTrue())
+			})
+
+			It("should clear the selection when the labels are released", func() {
+				policy.Spec.ReleaseOnSuspend = true
+
+				Expect(handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{})).To(Succeed())
+
+				Expect(policy.Status.SelectedNodes).To(BeEmpty())
+				Expect(policy.Status.SelectedCount).To(BeZero())
+				suspended := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionSuspended)
+				Expect(suspended).NotTo(BeNil())
+				Expect(suspended.Message).To(ContainSubstring("labels are released"))
+			})
+
+			It("should report NodeUpdateFailed when the labels could not be released", func() {
+				policy.Spec.ReleaseOnSuspend = true
+
+				err := handler.UpdatePolicyStatus(ctx, policy, ReconcileResult{Err: fmt.Errorf("failed to cleanup labels from node node-a")})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-a"}))
+				degraded := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionDegraded)
+				Expect(degraded).NotTo(BeNil())
+				Expect(degraded.Reason).To(Equal(nlpv1alpha1.ReasonNodeUpdateFailed))
+				Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionSuspended)).To(BeTrue())
+			})
 		})
 	})
 })
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/utils"
+)
+
+// PolicyConflict describes a label key a policy leaves to a higher-priority policy on a node
+type PolicyConflict struct {
+	// NodeName is the node on which both policies set the key
+	NodeName string
+
+	// Key is the label key both policies set to different values
+	Key string
+
+	// Winner is the name of the policy whose value is kept
+	Winner string
+}
+
+// PolicyConflicts is the list of label keys a policy leaves to other policies
+type PolicyConflicts []PolicyConflict
+
+// Without returns the labels to apply to a node, leaving out the keys lost to other policies on it
+func (c PolicyConflicts) Without(nodeName string, labels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	for _, conflict := range c {
+		if conflict.NodeName == nodeName {
+			delete(result, conflict.Key)
+		}
+	}
+	return result
+}
+
+// Keys returns the sorted label keys lost to other policies
+func (c PolicyConflicts) Keys() []string {
+	return c.unique(func(conflict PolicyConflict) string { return conflict.Key })
+}
+
+// Winners returns the sorted names of the policies winning the conflicts
+func (c PolicyConflicts) Winners() []string {
+	return c.unique(func(conflict PolicyConflict) string { return conflict.Winner })
+}
+
+// NodeNames returns the sorted names of the nodes with conflicts
+func (c PolicyConflicts) NodeNames() []string {
+	return c.unique(func(conflict PolicyConflict) string { return conflict.NodeName })
+}
+
+func (c PolicyConflicts) unique(field func(PolicyConflict) string) []string {
+	seen := make(map[string]bool, len(c))
+	values := []string{}
+	for _, conflict := range c {
+		value := field(conflict)
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// LabelKeyIndex maps label keys to the policies setting them
+type LabelKeyIndex map[string][]nlpv1alpha1.NodeLabelPolicy
+
+// NewLabelKeyIndex indexes the given policies by the label keys they set
+func NewLabelKeyIndex(policies []nlpv1alpha1.NodeLabelPolicy) LabelKeyIndex {
+	index := LabelKeyIndex{}
+	for _, policy := range policies {
+		for key := range policy.Spec.Labels {
+			index[key] = append(index[key], policy)
+		}
+	}
+	return index
+}
+
+// Conflicts returns the label keys of policy that are overridden by higher-priority policies on the given nodes
+// A node is contested by another policy once that policy selected it, as reported in its status, or while it
+// carries that policy's labels, and templated values are compared as rendered for the node
+func (idx LabelKeyIndex) Conflicts(policy *nlpv1alpha1.NodeLabelPolicy, nodes []corev1.Node) PolicyConflicts {
+	conflicts := PolicyConflicts{}
+	indexes := templateIndexes(policy.Name, nodes)
+
+	keys := make([]string, 0, len(policy.Spec.Labels))
+	for key := range policy.Spec.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, node := range nodes {
+		for _, key := range keys {
+			for _, other := range idx[key] {
+				if other.Name == policy.Name || !outranks(&other, policy) || !contests(&other, &node) {
+					continue
+				}
+				if labelValuesMatch(policy, &other, key, &node, indexes[node.Name]) {
+					continue
+				}
+				conflicts = append(conflicts, PolicyConflict{NodeName: node.Name, Key: key, Winner: other.Name})
+				break
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// labelValuesMatch reports whether two policies set a label key to the same value on a node, rendering
+// the policy's templated value with the given index and the other policy's with the index it recorded on the node
+// A value that can not be rendered never matches.
+func labelValuesMatch(policy, other *nlpv1alpha1.NodeLabelPolicy, key string, node *corev1.Node, index int) bool {
+	value, otherValue := policy.Spec.Labels[key], other.Spec.Labels[key]
+	if !utils.IsLabelTemplate(value) && !utils.IsLabelTemplate(otherValue) {
+		return value == otherValue
+	}
+
+	otherIndex, _ := recordedIndex(node, other.Name)
+	rendered, err := renderedLabelValue(policy, key, node, index)
+	if err != nil {
+		return false
+	}
+	otherRendered, err := renderedLabelValue(other, key, node, otherIndex)
+	return err == nil && rendered == otherRendered
+}
+
+// contests reports whether a policy selected the node or still labels it
+func contests(policy *nlpv1alpha1.NodeLabelPolicy, node *corev1.Node) bool {
+	return node.Labels[ManagedByLabelKey(policy.Name)] == managedByLabelValue ||
+		slices.Contains(policy.Status.SelectedNodes, node.Name)
+}
+
+// outranks reports whether policy a wins a conflict against policy b
+func outranks(a, b *nlpv1alpha1.NodeLabelPolicy) bool {
+	if a.Spec.Priority != b.Spec.Priority {
+		return a.Spec.Priority > b.Spec.Priority
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+var _ = Describe("LabelKeyIndex", func() {
+	It("should index policies by the label keys they set", func() {
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{
+			testPolicy("a", 0, map[string]string{"environment": "production", "tier": "gold"}),
+			testPolicy("b", 0, map[string]string{"environment": "staging"}),
+		})
+
+		Expect(index).To(HaveLen(2))
+		Expect(index["environment"]).To(HaveLen(2))
+		Expect(index["tier"]).To(HaveLen(1))
+	})
+
+	It("should leave a conflicting key to the policy with the higher priority", func() {
+		low := testPolicy("low", 1, map[string]string{"environment": "staging", "team": "web"})
+		high := testPolicy("high", 10, map[string]string{"environment": "production"})
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{low, high})
+		nodes := []corev1.Node{labeledBy(testNode("node-a", 0, nil), "low", "high"), labeledBy(testNode("node-b", 0, nil), "low")}
+
+		Expect(index.Conflicts(&low, nodes)).To(Equal(PolicyConflicts{
+			{NodeName: "node-a", Key: "environment", Winner: "high"},
+		}))
+		Expect(index.Conflicts(&high, nodes)).To(BeEmpty())
+	})
+
+	It("should break priority ties by policy name", func() {
+		a := testPolicy("policy-a", 5, map[string]string{"environment": "production"})
+		b := testPolicy("policy-b", 5, map[string]string{"environment": "staging"})
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{b, a})
+		nodes := []corev1.Node{labeledBy(testNode("node-a", 0, nil), "policy-a", "policy-b")}
+
+		Expect(index.Conflicts(&a, nodes)).To(BeEmpty())
+		Expect(index.Conflicts(&b, nodes)).To(Equal(PolicyConflicts{
+			{NodeName: "node-a", Key: "environment", Winner: "policy-a"},
+		}))
+	})
+
+	It("should not report policies setting the same value", func() {
+		low := testPolicy("low", 1, map[string]string{"environment": "production"})
+		high := testPolicy("high", 10, map[string]string{"environment": "production"})
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{low, high})
+
+		Expect(index.Conflicts(&low, []corev1.Node{labeledBy(testNode("node-a", 0, nil), "low", "high")})).To(BeEmpty())
+	})
+
+	It("should compare templated values as rendered for the node", func() {
+		low := testPolicy("low", 1, map[string]string{"zone": `{{ index .Node.Labels "topology.kubernetes.io/zone" }}`})
+		high := testPolicy("high", 10, map[string]string{"zone": "zone-a"})
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{low, high})
+		nodeA := labeledBy(testNode("node-a", 0, nil), "low", "high")
+		nodeA.Labels["topology.kubernetes.io/zone"] = "zone-a"
+		nodeB := labeledBy(testNode("node-b", 0, nil), "low", "high")
+		nodeB.Labels["topology.kubernetes.io/zone"] = "zone-b"
+
+		Expect(index.Conflicts(&low, []corev1.Node{nodeA, nodeB})).To(Equal(PolicyConflicts{
+			{NodeName: "node-b", Key: "zone", Winner: "high"},
+		}))
+	})
+
+	It("should render the index of the other policy as recorded on the node", func() {
+		low := testPolicy("low", 1, map[string]string{"shard": "{{ .Index }}"})
+		high := testPolicy("high", 10, map[string]string{"shard": "{{ .Index }}"})
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{low, high})
+		node := labeledBy(testNode("node-a", 0, nil), "high")
+		node.Annotations = map[string]string{IndexAnnotationKey("high"): "3"}
+
+		Expect(index.Conflicts(&low, []corev1.Node{node})).To(Equal(PolicyConflicts{
+			{NodeName: "node-a", Key: "shard", Winner: "high"},
+		}))
+	})
+
+	It("should report nodes the winning policy selected before it labels them", func() {
+		low := testPolicy("low", 1, map[string]string{"environment": "staging"})
+		high := testPolicy("high", 10, map[string]string{"environment": "production"})
+		high.Status.SelectedNodes = []string{"node-a"}
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{low, high})
+
+		nodes := []corev1.Node{labeledBy(testNode("node-a", 0, nil), "low"), labeledBy(testNode("node-b", 0, nil), "low")}
+
+		Expect(index.Conflicts(&low, nodes)).To(Equal(PolicyConflicts{
+			{NodeName: "node-a", Key: "environment", Winner: "high"},
+		}))
+	})
+
+	It("should not report nodes the winning policy does not label", func() {
+		low := testPolicy("low", 1, map[string]string{"environment": "staging"})
+		high := testPolicy("high", 10, map[string]string{"environment": "production"})
+		index := NewLabelKeyIndex([]nlpv1alpha1.NodeLabelPolicy{low, high})
+
+		Expect(index.Conflicts(&low, []corev1.Node{labeledBy(testNode("node-a", 0, nil), "low")})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("PolicyConflicts", func() {
+	conflicts := PolicyConflicts{
+		{NodeName: "node-b", Key: "environment", Winner: "high"},
+		{NodeName: "node-a", Key: "environment", Winner: "high"},
+		{NodeName: "node-a", Key: "team", Winner: "other"},
+	}
+
+	It("should leave out the keys lost on the given node only", func() {
+		labels := map[string]string{"environment": "staging", "team": "web", "tier": "gold"}
+
+		Expect(conflicts.Without("node-a", labels)).To(Equal(map[string]string{"tier": "gold"}))
+		Expect(conflicts.Without("node-b", labels)).To(Equal(map[string]string{"team": "web", "tier": "gold"}))
+		Expect(conflicts.Without("node-c", labels)).To(Equal(labels))
+		Expect(labels).To(HaveLen(3))
+	})
+
+	It("should summarize keys, winners and nodes", func() {
+		Expect(conflicts.Keys()).To(Equal([]string{"environment", "team"}))
+		Expect(conflicts.Winners()).To(Equal([]string{"high", "other"}))
+		Expect(conflicts.NodeNames()).To(Equal([]string{"node-a", "node-b"}))
+	})
+})
+
+var _ = Describe("DetectPolicyConflicts", func() {
+	It("should compare the policy against all NodeLabelPolicies", func() {
+		fakeClient := &k8sfakes.FakeClient{}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*nlpv1alpha1.NodeLabelPolicyList).Items = []nlpv1alpha1.NodeLabelPolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "winner"},
+					Spec: nlpv1alpha1.NodeLabelPolicySpec{
+						Labels:   map[string]string{"environment": "production"},
+						Priority: 100,
+					},
+				},
+			}
+			return nil
+		}
+		handler := NewNodeLabelPolicyHandler(fakeClient)
+
+		policy := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "loser"},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels: map[string]string{"environment": "staging"},
+			},
+		}
+		nodes := []corev1.Node{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-a",
+				Labels: map[string]string{ManagedByLabelKey("winner"): "true"},
+			},
+		}}
+
+		conflicts, err := handler.DetectPolicyConflicts(context.Background(), policy, nodes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conflicts).To(Equal(PolicyConflicts{
+			{NodeName: "node-a", Key: "environment", Winner: "winner"},
+		}))
+	})
+})
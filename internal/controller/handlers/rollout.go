@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/constants"
+)
+
+// rolloutLimits returns how many nodes above and below the desired count may carry the policy's labels while they move
+// Percentages are taken of the desired count, rounding the surge up and the unavailability down.
+// When both limits resolve to zero the surge is raised to one, so that the labels can still move.
+func rolloutLimits(rollout *nlpv1alpha1.NodeLabelPolicyRollout, desiredCount int) (int, int, error) {
+	maxSurgeValue := intstr.ValueOrDefault(rollout.MaxSurge, intstr.FromInt32(constants.DefaultMaxSurge))
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(maxSurgeValue, desiredCount, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rollout maxSurge %q: %w", maxSurgeValue.String(), err)
+	}
+
+	maxUnavailableValue := intstr.ValueOrDefault(rollout.MaxUnavailable, intstr.FromInt32(constants.DefaultMaxUnavailable))
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailableValue, desiredCount, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rollout maxUnavailable %q: %w", maxUnavailableValue.String(), err)
+	}
+
+	if maxSurge <= 0 && maxUnavailable <= 0 {
+		maxSurge = 1
+	}
+	return max(maxSurge, 0), max(maxUnavailable, 0), nil
+}
+
+// limitRollout holds back the label moves of the plan that exceed the rollout limits until a later reconciliation
+// Newly selected nodes are labeled first, while at most maxSurge nodes above the desired count carry the labels,
+// then deselected nodes are unlabeled, while at most maxUnavailable nodes below the desired count are left.
+// Selected nodes that already carry the labels are updated regardless, since no label moves.
+// The limits are computed from the labels the nodes carry, so an interrupted rollout resumes where it stopped.
+func limitRollout(plan LabelPlan, rollout *nlpv1alpha1.NodeLabelPolicyRollout, policyName string, labeledCount, desiredCount int) (LabelPlan, error) {
+	if rollout == nil {
+		return plan, nil
+	}
+
+	maxSurge, maxUnavailable, err := rolloutLimits(rollout, desiredCount)
+	if err != nil {
+		return LabelPlan{}, err
+	}
+
+	managedByLabelKey := ManagedByLabelKey(policyName)
+
+	var limited LabelPlan
+	toLabel := desiredCount + maxSurge - labeledCount
+	for _, planned := range plan.Apply {
+		switch {
+		case planned.Node.Labels[managedByLabelKey] == managedByLabelValue:
+			limited.Apply = append(limited.Apply, planned)
+		case toLabel > 0:
+			limited.Apply = append(limited.Apply, planned)
+			labeledCount++
+			toLabel--
+		default:
+			limited.PendingLabel = append(limited.PendingLabel, planned.Node.Name)
+		}
+	}
+
+	toUnlabel := labeledCount - (desiredCount - maxUnavailable)
+	for _, node := range plan.Remove {
+		if toUnlabel > 0 {
+			limited.Remove = append(limited.Remove, node)
+			toUnlabel--
+			continue
+		}
+		limited.PendingUnlabel = append(limited.PendingUnlabel, node.Name)
+	}
+
+	return limited, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+var _ = Describe("Rollout", func() {
+	var policy *nlpv1alpha1.NodeLabelPolicy
+
+	BeforeEach(func() {
+		policy = &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels:  map[string]string{"workload": "monitoring"},
+				Rollout: &nlpv1alpha1.NodeLabelPolicyRollout{},
+			},
+		}
+	})
+
+	DescribeTable("rolloutLimits",
+		func(maxSurge, maxUnavailable *intstr.IntOrString, desiredCount, expectedSurge, expectedUnavailable int) {
+			surge, unavailable, err := rolloutLimits(&nlpv1alpha1.NodeLabelPolicyRollout{
+				MaxSurge:       maxSurge,
+				MaxUnavailable: maxUnavailable,
+			}, desiredCount)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(surge).To(Equal(expectedSurge))
+			Expect(unavailable).To(Equal(expectedUnavailable))
+		},
+		Entry("defaults", nil, nil, 4, 1, 0),
+		Entry("absolute numbers", ptr.To(intstr.FromInt32(2)), ptr.To(intstr.FromInt32(1)), 4, 2, 1),
+		Entry("percentages", ptr.To(intstr.FromString("30%")), ptr.To(intstr.FromString("30%")), 4, 2, 1),
+		Entry("both resolving to zero", ptr.To(intstr.FromString("10%")), ptr.To(intstr.FromInt32(0)), 0, 1, 0),
+		Entry("only unavailability", ptr.To(intstr.FromInt32(0)), ptr.To(intstr.FromInt32(1)), 4, 0, 1),
+	)
+
+	It("should return an error for an invalid limit", func() {
+		_, _, err := rolloutLimits(&nlpv1alpha1.NodeLabelPolicyRollout{MaxSurge: ptr.To(intstr.FromString("one"))}, 3)
+		Expect(err).To(MatchError(ContainSubstring(`invalid rollout maxSurge "one"`)))
+	})
+
+	Describe("PlanLabelChanges", func() {
+		plan := func(labeled, selected []corev1.Node) LabelPlan {
+			plan, err := NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{}).PlanLabelChanges(policy, labeled, selected, nil)
+			Expect(err).NotTo(HaveOccurred())
+			return plan
+		}
+
+		It("should label one new node before unlabeling one old node by default", func() {
+			result := plan(labeledNodes("test", "node-a", "node-b", "node-c"), testNodes("node-d", "node-e", "node-f"))
+
+			Expect(result.NodesToLabel()).To(Equal([]string{"node-d"}))
+			Expect(result.NodesToUnlabel()).To(Equal([]string{"node-a"}))
+			Expect(result.PendingLabel).To(Equal([]string{"node-e", "node-f"}))
+			Expect(result.PendingUnlabel).To(Equal([]string{"node-b", "node-c"}))
+			Expect(result.RollingOut()).To(BeTrue())
+		})
+
+		It("should resume from the labels the nodes carry", func() {
+			labeled := append(labeledNodes("test", "node-b", "node-c"), labeledNodes("test", "node-d")...)
+			selected := append(labeledNodes("test", "node-d"), testNodes("node-e", "node-f")...)
+
+			result := plan(labeled, selected)
+
+			// node-d is only updated, since it already carries the labels
+			Expect(result.NodesToLabel()).To(Equal([]string{"node-d", "node-e"}))
+			Expect(result.NodesToUnlabel()).To(Equal([]string{"node-b"}))
+			Expect(result.PendingLabel).To(Equal([]string{"node-f"}))
+			Expect(result.PendingUnlabel).To(Equal([]string{"node-c"}))
+		})
+
+		It("should unlabel old nodes first when only unavailability is allowed", func() {
+			policy.Spec.Rollout.MaxSurge = ptr.To(intstr.FromInt32(0))
+			policy.Spec.Rollout.MaxUnavailable = ptr.To(intstr.FromInt32(1))
+
+			result := plan(labeledNodes("test", "node-a", "node-b"), testNodes("node-c", "node-d"))
+
+			Expect(result.NodesToLabel()).To(BeEmpty())
+			Expect(result.NodesToUnlabel()).To(Equal([]string{"node-a"}))
+			Expect(result.PendingLabel).To(Equal([]string{"node-c", "node-d"}))
+			Expect(result.PendingUnlabel).To(Equal([]string{"node-b"}))
+		})
+
+		It("should not hold back labels when the selection grows or shrinks", func() {
+			result := plan(labeledNodes("test", "node-a"), testNodes("node-b", "node-c"))
+			Expect(result.NodesToLabel()).To(Equal([]string{"node-b", "node-c"}))
+			Expect(result.NodesToUnlabel()).To(Equal([]string{"node-a"}))
+			Expect(result.RollingOut()).To(BeFalse())
+
+			result = plan(labeledNodes("test", "node-a", "node-b", "node-c"), nil)
+			Expect(result.NodesToUnlabel()).To(Equal([]string{"node-a", "node-b", "node-c"}))
+			Expect(result.RollingOut()).To(BeFalse())
+		})
+
+		It("should move all labels at once without rollout limits", func() {
+			policy.Spec.Rollout = nil
+
+			result := plan(labeledNodes("test", "node-a", "node-b"), testNodes("node-c", "node-d"))
+			Expect(result.NodesToLabel()).To(Equal([]string{"node-c", "node-d"}))
+			Expect(result.NodesToUnlabel()).To(Equal([]string{"node-a", "node-b"}))
+			Expect(result.RollingOut()).To(BeFalse())
+		})
+	})
+
+	Describe("UpdatePolicyStatus", func() {
+		It("should report the nodes carrying the labels and the held back moves", func() {
+			fakeClient := &k8sfakes.FakeClient{}
+			fakeClient.StatusReturns(&k8sfakes.FakeStatusWriter{})
+			policy.Status.SelectedNodes = []string{"node-a", "node-b"}
+
+			err := NewNodeLabelPolicyHandler(fakeClient).UpdatePolicyStatus(context.Background(), policy, ReconcileResult{
+				Selection: NodeSelection{Nodes: testNodes("node-c", "node-d"), DesiredCount: 2, EligibleCount: 4},
+				Plan:      LabelPlan{PendingLabel: []string{"node-d"}, PendingUnlabel: []string{"node-b"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-c", "node-b"}))
+			Expect(policy.Status.SelectedCount).To(Equal(int32(2)))
+			Expect(policy.Status.Rollout).To(Equal(&nlpv1alpha1.NodeLabelPolicyPlan{
+				NodesToLabel:   []string{"node-d"},
+				NodesToUnlabel: []string{"node-b"},
+			}))
+
+			progressing := meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionProgressing)
+			Expect(progressing).NotTo(BeNil())
+			Expect(progressing.Status).To(Equal(metav1.ConditionTrue))
+			Expect(progressing.Reason).To(Equal(nlpv1alpha1.ReasonRollingOut))
+
+			err = NewNodeLabelPolicyHandler(fakeClient).UpdatePolicyStatus(context.Background(), policy, ReconcileResult{
+				Selection: NodeSelection{Nodes: testNodes("node-c", "node-d"), DesiredCount: 2, EligibleCount: 4},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.Status.SelectedNodes).To(Equal([]string{"node-c", "node-d"}))
+			Expect(policy.Status.Rollout).To(BeNil())
+			Expect(meta.FindStatusCondition(policy.Status.Conditions, nlpv1alpha1.ConditionProgressing).Reason).
+				To(Equal(nlpv1alpha1.ReasonSelectionChanged))
+		})
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+)
+
+// nodeScorer scores nodes for the score strategy, nodes with higher scores being selected first
+type nodeScorer interface {
+	// score returns the score of each node, in the order of the nodes
+	score(nodes []corev1.Node) []float64
+}
+
+// attributeScorer weights a numeric attribute scaled to [0, 1] between its lowest and highest value among the nodes
+// Nodes without the attribute score as the lowest value, and all nodes score 0 when the attribute does not vary.
+type attributeScorer struct {
+	weight float64
+
+	// value returns the attribute of the node, or false if the node does not have it
+	value func(node *corev1.Node) (float64, bool)
+}
+
+func (s attributeScorer) score(nodes []corev1.Node) []float64 {
+	values := make([]float64, len(nodes))
+	present := make([]bool, len(nodes))
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for i := range nodes {
+		value, ok := s.value(&nodes[i])
+		if !ok {
+			continue
+		}
+		values[i], present[i] = value, true
+		lowest, highest = math.Min(lowest, value), math.Max(highest, value)
+	}
+
+	scores := make([]float64, len(nodes))
+	if highest <= lowest {
+		return scores
+	}
+	for i := range nodes {
+		if present[i] {
+			scores[i] = s.weight * (values[i] - lowest) / (highest - lowest)
+		}
+	}
+	return scores
+}
+
+// matchScorer adds its weight to the score of the nodes it matches
+type matchScorer struct {
+	weight float64
+
+	// matches reports whether the node is matched
+	matches func(node *corev1.Node) bool
+}
+
+func (s matchScorer) score(nodes []corev1.Node) []float64 {
+	scores := make([]float64, len(nodes))
+	for i := range nodes {
+		if s.matches(&nodes[i]) {
+			scores[i] = s.weight
+		}
+	}
+	return scores
+}
+
+// nodeScorers returns the scorers of the attributes the scoring weights, leaving out attributes with a zero weight
+func nodeScorers(scoring *nlpv1alpha1.NodeScoring, topologyKey string, now time.Time) []nodeScorer {
+	if scoring == nil {
+		return nil
+	}
+
+	var scorers []nodeScorer
+	addAttribute := func(weight int32, value func(node *corev1.Node) (float64, bool)) {
+		if weight != 0 {
+			scorers = append(scorers, attributeScorer{weight: float64(weight), value: value})
+		}
+	}
+	addMatch := func(weight int32, matches func(node *corev1.Node) bool) {
+		if weight != 0 {
+			scorers = append(scorers, matchScorer{weight: float64(weight), matches: matches})
+		}
+	}
+
+	addAttribute(scoring.CPU, allocatable(corev1.ResourceCPU))
+	addAttribute(scoring.Memory, allocatable(corev1.ResourceMemory))
+	addAttribute(scoring.Age, func(node *corev1.Node) (float64, bool) {
+		return now.Sub(node.CreationTimestamp.Time).Seconds(), true
+	})
+	for _, labelValue := range scoring.LabelValues {
+		key := labelValue.Key
+		addAttribute(labelValue.Weight, func(node *corev1.Node) (float64, bool) {
+			value, err := strconv.ParseFloat(node.Labels[key], 64)
+			return value, err == nil && !math.IsNaN(value) && !math.IsInf(value, 0)
+		})
+	}
+	for _, zone := range scoring.Zones {
+		value := zone.Zone
+		addMatch(zone.Weight, func(node *corev1.Node) bool {
+			current, ok := node.Labels[topologyKey]
+			return ok && current == value
+		})
+	}
+	for _, label := range scoring.Labels {
+		key, value := label.Key, label.Value
+		addMatch(label.Weight, func(node *corev1.Node) bool {
+			current, ok := node.Labels[key]
+			return ok && (value == "" || current == value)
+		})
+	}
+
+	return scorers
+}
+
+// allocatable returns the value of an allocatable resource of a node
+func allocatable(resource corev1.ResourceName) func(node *corev1.Node) (float64, bool) {
+	return func(node *corev1.Node) (float64, bool) {
+		quantity, ok := node.Status.Allocatable[resource]
+		if !ok {
+			return 0, false
+		}
+		return quantity.AsApproximateFloat64(), true
+	}
+}
+
+// scoreNodes orders nodes by the sum of their scores, highest first, with ties broken by node name
+func scoreNodes(nodes []corev1.Node, scorers []nodeScorer) []corev1.Node {
+	totals := make(map[string]float64, len(nodes))
+	for _, scorer := range scorers {
+		for i, score := range scorer.score(nodes) {
+			totals[nodes[i].Name] += score
+		}
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if totals[nodes[i].Name] != totals[nodes[j].Name] {
+			return totals[nodes[i].Name] > totals[nodes[j].Name]
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+var _ = Describe("Score strategy", func() {
+	var nodes []corev1.Node
+
+	newNode := func(name string, age time.Duration, cpu, memory string, labels map[string]string) corev1.Node {
+		node := testNode(name, age, labels)
+		node.Status.Allocatable = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}
+		return node
+	}
+
+	BeforeEach(func() {
+		nodes = []corev1.Node{
+			newNode("node-a", 3*time.Hour, "2", "16Gi", map[string]string{
+				"topology.kubernetes.io/zone": "zone-a",
+				"example.com/bandwidth":       "10",
+			}),
+			newNode("node-b", 2*time.Hour, "8", "8Gi", map[string]string{
+				"topology.kubernetes.io/zone": "zone-b",
+				"example.com/bandwidth":       "25",
+				"example.com/ingress":         "preferred",
+			}),
+			newNode("node-c", time.Hour, "4", "32Gi", map[string]string{
+				"topology.kubernetes.io/zone": "zone-c",
+				"example.com/bandwidth":       "fast",
+				"example.com/ingress":         "allowed",
+			}),
+		}
+	})
+
+	DescribeTable("scoreNodes",
+		func(scoring *nlpv1alpha1.NodeScoring, expected []string) {
+			scored := scoreNodes(nodes, nodeScorers(scoring, "topology.kubernetes.io/zone", time.Now()))
+
+			names := make([]string, len(scored))
+			for i, node := range scored {
+				names[i] = node.Name
+			}
+			Expect(names).To(Equal(expected))
+		},
+		Entry("allocatable CPU", &nlpv1alpha1.NodeScoring{CPU: 1}, []string{"node-b", "node-c", "node-a"}),
+		Entry("allocatable memory", &nlpv1alpha1.NodeScoring{Memory: 1}, []string{"node-c", "node-a", "node-b"}),
+		Entry("age", &nlpv1alpha1.NodeScoring{Age: 1}, []string{"node-a", "node-b", "node-c"}),
+		Entry("negative age weight", &nlpv1alpha1.NodeScoring{Age: -1}, []string{"node-c", "node-b", "node-a"}),
+		Entry("numeric label value with a non-numeric value scoring lowest",
+			&nlpv1alpha1.NodeScoring{LabelValues: []nlpv1alpha1.NodeLabelValueWeight{{Key: "example.com/bandwidth", Weight: 1}}},
+			[]string{"node-b", "node-a", "node-c"}),
+		Entry("zone preference",
+			&nlpv1alpha1.NodeScoring{Zones: []nlpv1alpha1.NodeZoneWeight{{Zone: "zone-c", Weight: 5}, {Zone: "zone-a", Weight: 2}}},
+			[]string{"node-c", "node-a", "node-b"}),
+		Entry("label with any value",
+			&nlpv1alpha1.NodeScoring{Labels: []nlpv1alpha1.NodeLabelWeight{{Key: "example.com/ingress", Weight: 1}}},
+			[]string{"node-b", "node-c", "node-a"}),
+		Entry("label with a value",
+			&nlpv1alpha1.NodeScoring{Labels: []nlpv1alpha1.NodeLabelWeight{{Key: "example.com/ingress", Value: "allowed", Weight: 1}}},
+			[]string{"node-c", "node-a", "node-b"}),
+		Entry("weighted sum of attributes",
+			&nlpv1alpha1.NodeScoring{
+				CPU:    2,
+				Memory: 1,
+				Zones:  []nlpv1alpha1.NodeZoneWeight{{Zone: "zone-a", Weight: 3}},
+			},
+			// node-a: 0 + 0.33 + 3, node-b: 2 + 0 + 0, node-c: 0.67 + 1 + 0
+			[]string{"node-a", "node-b", "node-c"}),
+		Entry("ties broken by node name", &nlpv1alpha1.NodeScoring{}, []string{"node-a", "node-b", "node-c"}),
+		Entry("no scoring", nil, []string{"node-a", "node-b", "node-c"}),
+	)
+
+	It("should score all nodes 0 for an attribute that does not vary", func() {
+		scorer := attributeScorer{weight: 1, value: func(*corev1.Node) (float64, bool) { return 4, true }}
+		Expect(scorer.score(nodes)).To(Equal([]float64{0, 0, 0}))
+	})
+
+	Describe("SelectNodes", func() {
+		var policy *nlpv1alpha1.NodeLabelPolicy
+
+		BeforeEach(func() {
+			policy = &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "score",
+						Count: ptr.To(intstr.FromInt32(2)),
+						Score: &nlpv1alpha1.NodeScoring{CPU: 1},
+					},
+				},
+			}
+		})
+
+		It("should select the nodes with the highest scores", func() {
+			selection, err := NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{}).SelectNodes(context.Background(), nodes, policy, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"node-b", "node-c"}))
+		})
+
+		It("should keep the current nodes first when sticky", func() {
+			policy.Spec.Strategy.Stickiness = "sticky"
+
+			selection, err := NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{}).SelectNodes(context.Background(), nodes, policy, []string{"node-a"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"node-a", "node-b"}))
+		})
+
+		It("should weight zones by the topology key", func() {
+			policy.Spec.Strategy.TopologyKey = "example.com/ingress"
+			policy.Spec.Strategy.Score = &nlpv1alpha1.NodeScoring{Zones: []nlpv1alpha1.NodeZoneWeight{{Zone: "allowed", Weight: 1}}}
+
+			selection, err := NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{}).SelectNodes(context.Background(), nodes, policy, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.NodeNames()).To(Equal([]string{"node-c", "node-a"}))
+		})
+	})
+})
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/jivvon/node-label-controller/internal/constants"
+)
+
+// TaintsAnnotationKey returns the node annotation recording the taints a policy added to the node
+func TaintsAnnotationKey(policyName string) string {
+	return fmt.Sprintf("%s.%s/taints", constants.ManagedByLabelPrefix, policyName)
+}
+
+// ownedTaints returns the taints the policy recorded as added to the node
+// An unreadable record is treated as empty, so that taints are never removed without proof of ownership
+func ownedTaints(node *corev1.Node, policyName string) []corev1.Taint {
+	value, ok := node.Annotations[TaintsAnnotationKey(policyName)]
+	if !ok {
+		return nil
+	}
+
+	var taints []corev1.Taint
+	if err := json.Unmarshal([]byte(value), &taints); err != nil {
+		return nil
+	}
+	return taints
+}
+
+// setTaints enforces the policy's taints on the node and records the taints the policy owns in its annotation.
+// Owned taints keep their position and take the desired value, owned taints that are no longer desired are
+// removed, and a desired taint is only added if no taint with the same key and effect exists, so taints added
+// by others are never changed or claimed. It reports whether the node changed.
+func setTaints(node *corev1.Node, policyName string, taints []corev1.Taint) bool {
+	owned := ownedTaints(node, policyName)
+
+	result := make([]corev1.Taint, 0, len(node.Spec.Taints)+len(taints))
+	var nowOwned []corev1.Taint
+	for _, taint := range node.Spec.Taints {
+		if findTaint(owned, &taint) == nil {
+			result = append(result, taint)
+			continue
+		}
+		if desired := findTaint(taints, &taint); desired != nil {
+			result = append(result, *desired)
+			nowOwned = append(nowOwned, *desired)
+		}
+	}
+	for _, taint := range taints {
+		if findTaint(result, &taint) == nil {
+			result = append(result, taint)
+			nowOwned = append(nowOwned, taint)
+		}
+	}
+
+	annotationKey := TaintsAnnotationKey(policyName)
+	previousAnnotation, hadAnnotation := node.Annotations[annotationKey]
+	changed := !equality.Semantic.DeepEqual(node.Spec.Taints, result)
+
+	if len(result) == 0 {
+		result = nil
+	}
+	node.Spec.Taints = result
+
+	if len(nowOwned) == 0 {
+		delete(node.Annotations, annotationKey)
+		return changed || hadAnnotation
+	}
+
+	annotation, _ := json.Marshal(nowOwned)
+	if node.Annotations == nil {
+		node.Annotations = make(map[string]string)
+	}
+	node.Annotations[annotationKey] = string(annotation)
+
+	return changed || previousAnnotation != string(annotation)
+}
+
+// findTaint returns the taint with the same key and effect, or nil if there is none
+func findTaint(taints []corev1.Taint, taint *corev1.Taint) *corev1.Taint {
+	for i := range taints {
+		if taints[i].MatchTaint(taint) {
+			return &taints[i]
+		}
+	}
+	return nil
+}
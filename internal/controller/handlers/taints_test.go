@@ -0,0 +1,238 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+var _ = Describe("Taints", func() {
+	const annotationKey = "nlp.test/taints"
+
+	var (
+		node      *corev1.Node
+		reserved  corev1.Taint
+		unrelated corev1.Taint
+	)
+
+	BeforeEach(func() {
+		reserved = corev1.Taint{Key: "dedicated", Value: "monitoring", Effect: corev1.TaintEffectNoSchedule}
+		unrelated = corev1.Taint{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule}
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node", ResourceVersion: "7"},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{unrelated}},
+		}
+	})
+
+	Describe("setTaints", func() {
+		It("should add the taints and record them as owned", func() {
+			Expect(setTaints(node, "test", []corev1.Taint{reserved})).To(BeTrue())
+
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{unrelated, reserved}))
+			Expect(node.Annotations).To(HaveKeyWithValue(annotationKey, `[{"key":"dedicated","value":"monitoring","effect":"NoSchedule"}]`))
+		})
+
+		It("should report no change once the taints are applied", func() {
+			setTaints(node, "test", []corev1.Taint{reserved})
+
+			Expect(setTaints(node, "test", []corev1.Taint{reserved})).To(BeFalse())
+		})
+
+		It("should update the value of an owned taint in place", func() {
+			setTaints(node, "test", []corev1.Taint{reserved})
+			node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: "other", Effect: corev1.TaintEffectNoExecute})
+
+			reserved.Value = "logging"
+			Expect(setTaints(node, "test", []corev1.Taint{reserved})).To(BeTrue())
+
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{
+				unrelated,
+				reserved,
+				{Key: "other", Effect: corev1.TaintEffectNoExecute},
+			}))
+		})
+
+		It("should remove owned taints that are no longer desired", func() {
+			setTaints(node, "test", []corev1.Taint{reserved})
+
+			Expect(setTaints(node, "test", nil)).To(BeTrue())
+
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{unrelated}))
+			Expect(node.Annotations).NotTo(HaveKey(annotationKey))
+		})
+
+		It("should neither change nor claim a taint with the same key and effect added by someone else", func() {
+			existing := corev1.Taint{Key: "dedicated", Value: "logging", Effect: corev1.TaintEffectNoSchedule}
+			node.Spec.Taints = append(node.Spec.Taints, existing)
+
+			Expect(setTaints(node, "test", []corev1.Taint{reserved})).To(BeFalse())
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{unrelated, existing}))
+			Expect(node.Annotations).NotTo(HaveKey(annotationKey))
+
+			Expect(setTaints(node, "test", nil)).To(BeFalse())
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{unrelated, existing}))
+		})
+
+		It("should not remove taints when the record of owned taints is unreadable", func() {
+			node.Spec.Taints = append(node.Spec.Taints, reserved)
+			node.Annotations = map[string]string{annotationKey: "not json"}
+
+			Expect(setTaints(node, "test", nil)).To(BeTrue())
+
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{unrelated, reserved}))
+			Expect(node.Annotations).NotTo(HaveKey(annotationKey))
+		})
+
+		It("should keep the taints of other policies", func() {
+			setTaints(node, "other-policy", []corev1.Taint{{Key: "other", Effect: corev1.TaintEffectNoExecute}})
+			setTaints(node, "test", []corev1.Taint{reserved})
+
+			Expect(setTaints(node, "test", nil)).To(BeTrue())
+
+			Expect(node.Spec.Taints).To(Equal([]corev1.Taint{unrelated, {Key: "other", Effect: corev1.TaintEffectNoExecute}}))
+			Expect(node.Annotations).To(HaveKey("nlp.other-policy/taints"))
+		})
+	})
+
+	Describe("ApplyTaintsToNode", func() {
+		var (
+			fakeClient *k8sfakes.FakeClient
+			handler    NodeLabelPolicyHandler
+			ctx        context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			fakeClient = &k8sfakes.FakeClient{}
+			handler = NewNodeLabelPolicyHandler(fakeClient)
+		})
+
+		It("should patch the taints with an optimistic lock", func() {
+			changed, err := handler.ApplyTaintsToNode(ctx, node, "test", []corev1.Taint{reserved})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(node.Spec.Taints).To(ContainElement(reserved))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+			Expect(patch.Type()).To(Equal(types.MergePatchType))
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(fmt.Sprintf(
+				`{"metadata":{"annotations":{%q:%q},"resourceVersion":"7"},"spec":{"taints":[{"key":"node.kubernetes.io/unschedulable","effect":"NoSchedule"},{"key":"dedicated","value":"monitoring","effect":"NoSchedule"}]}}`,
+				annotationKey, `[{"key":"dedicated","value":"monitoring","effect":"NoSchedule"}]`)))
+		})
+
+		It("should not patch a node that already carries the taints", func() {
+			setTaints(node, "test", []corev1.Taint{reserved})
+
+			changed, err := handler.ApplyTaintsToNode(ctx, node, "test", []corev1.Taint{reserved})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(fakeClient.PatchCallCount()).To(Equal(0))
+		})
+
+		It("should return a NodeUpdateError when the patch fails", func() {
+			fakeClient.PatchReturns(fmt.Errorf("the object has been modified"))
+
+			_, err := handler.ApplyTaintsToNode(ctx, node, "test", []corev1.Taint{reserved})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to taint node test-node"))
+
+			var nodeErr *NodeUpdateError
+			Expect(errors.As(err, &nodeErr)).To(BeTrue())
+		})
+
+		It("should remove owned taints when the policy releases the node", func() {
+			setTaints(node, "test", []corev1.Taint{reserved})
+			node.Labels = map[string]string{ManagedByLabelKey("test"): managedByLabelValue}
+
+			released, err := handler.RemoveLabelsFromNodes(ctx, []corev1.Node{*node}, "test", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(HaveLen(1))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(2))
+			_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+			Expect(patch.Type()).To(Equal(types.MergePatchType))
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(
+				`{"metadata":{"annotations":null,"resourceVersion":"7"},"spec":{"taints":[{"key":"node.kubernetes.io/unschedulable","effect":"NoSchedule"}]}}`))
+
+			_, _, patch, _ = fakeClient.PatchArgsForCall(1)
+			Expect(patch.Type()).To(Equal(types.ApplyPatchType))
+		})
+	})
+
+	Describe("PlanLabelChanges", func() {
+		It("should plan selected nodes whose taints differ", func() {
+			policy := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       nlpv1alpha1.NodeLabelPolicySpec{Taints: []corev1.Taint{reserved}},
+			}
+			// the policy owns its labels but the taint is missing
+			node.Labels = map[string]string{ManagedByLabelKey("test"): managedByLabelValue}
+			node.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "nlp/test",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:nlp.test/managed-by":{}}}}`)},
+				},
+			}
+
+			plan, err := NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{}).PlanLabelChanges(policy, nil, []corev1.Node{*node}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(HaveLen(1))
+			Expect(plan.Apply[0].Taints).To(Equal([]corev1.Taint{reserved}))
+
+			setTaints(node, "test", []corev1.Taint{reserved})
+			plan, err = NewNodeLabelPolicyHandler(&k8sfakes.FakeClient{}).PlanLabelChanges(policy, nil, []corev1.Node{*node}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Apply).To(BeEmpty())
+		})
+	})
+
+	It("should keep the taints but drop their record when orphaning a node", func() {
+		fakeClient := &k8sfakes.FakeClient{}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			setTaints(node, "test", []corev1.Taint{reserved})
+			node.Labels = map[string]string{ManagedByLabelKey("test"): managedByLabelValue}
+			list.(*corev1.NodeList).Items = []corev1.Node{*node}
+			return nil
+		}
+
+		_, err := NewNodeLabelPolicyHandler(fakeClient).OrphanLabelsOnAllNodes(context.Background(), "test")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, obj, patch, _ := fakeClient.PatchArgsForCall(0)
+		data, err := patch.Data(obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchJSON(`{"metadata":{"annotations":null,"labels":null,"managedFields":[{}]}}`))
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/constants"
+	"github.com/jivvon/node-label-controller/internal/utils"
+)
+
+// IndexAnnotationKey returns the node annotation recording the index a policy renders its label templates with on the node
+func IndexAnnotationKey(policyName string) string {
+	return fmt.Sprintf("%s.%s/index", constants.ManagedByLabelPrefix, policyName)
+}
+
+// hasLabelTemplates reports whether any of the labels has a templated value
+func hasLabelTemplates(labels map[string]string) bool {
+	for _, value := range labels {
+		if utils.IsLabelTemplate(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordedIndex returns the template index the policy recorded on a node it labeled, or false if there is none
+func recordedIndex(node *corev1.Node, policyName string) (int, bool) {
+	if node.Labels[ManagedByLabelKey(policyName)] != managedByLabelValue {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(node.Annotations[IndexAnnotationKey(policyName)])
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// templateIndexes assigns each selected node an index from 0 to N-1 the policy's label templates are rendered with
+// Nodes keep the index recorded when the policy labeled them as long as it is below the number of selected nodes,
+// so that a selection change does not renumber the other nodes, and the remaining nodes take the lowest free
+// indexes in selection order.
+func templateIndexes(policyName string, selectedNodes []corev1.Node) map[string]int {
+	indexes := make(map[string]int, len(selectedNodes))
+	used := make(map[int]bool, len(selectedNodes))
+	for _, node := range selectedNodes {
+		if index, ok := recordedIndex(&node, policyName); ok && index < len(selectedNodes) && !used[index] {
+			indexes[node.Name] = index
+			used[index] = true
+		}
+	}
+
+	next := 0
+	for _, node := range selectedNodes {
+		if _, ok := indexes[node.Name]; ok {
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		indexes[node.Name] = next
+		used[next] = true
+	}
+
+	return indexes
+}
+
+// indexAnnotation returns the annotations to apply to a selected node with the index the policy renders its
+// label templates with on the node
+func indexAnnotation(policyName string, annotations map[string]string, index int) map[string]string {
+	withIndex := make(map[string]string, len(annotations)+1)
+	for key, value := range annotations {
+		withIndex[key] = value
+	}
+	withIndex[IndexAnnotationKey(policyName)] = strconv.Itoa(index)
+	return withIndex
+}
+
+// renderedLabelValue returns the value a policy sets a label key to on a node, rendering a templated value
+// with the given index
+func renderedLabelValue(policy *nlpv1alpha1.NodeLabelPolicy, key string, node *corev1.Node, index int) (string, error) {
+	value := policy.Spec.Labels[key]
+	if !utils.IsLabelTemplate(value) {
+		return value, nil
+	}
+
+	rendered, err := utils.RenderLabelTemplates(map[string]string{key: value}, node, index)
+	if err != nil {
+		return "", err
+	}
+	return rendered[key], nil
+}
@@ -19,12 +19,17 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -35,31 +40,39 @@ import (
 	"github.com/jivvon/node-label-controller/internal/constants"
 	"github.com/jivvon/node-label-controller/internal/controller/handlers"
 	"github.com/jivvon/node-label-controller/internal/external/k8s"
+	"github.com/jivvon/node-label-controller/internal/metrics"
+	"github.com/jivvon/node-label-controller/internal/utils"
 )
 
 type NodeLabelPolicyReconciler struct {
-	client  k8s.Client
-	handler handlers.NodeLabelPolicyHandler
-	Scheme  *runtime.Scheme
+	client   k8s.Client
+	handler  handlers.NodeLabelPolicyHandler
+	recorder record.EventRecorder
+	Scheme   *runtime.Scheme
 }
 
 // +kubebuilder:rbac:groups=nlp.lento.dev,resources=nodelabelpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nlp.lento.dev,resources=nodelabelpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nlp.lento.dev,resources=nodelabelpolicies/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *NodeLabelPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
 	nodeLabelPolicy := &nlpv1alpha1.NodeLabelPolicy{}
 	if err := r.client.Get(ctx, req.NamespacedName, nodeLabelPolicy); err != nil {
-		if errors.IsNotFound(err) {
+		if apierrors.IsNotFound(err) {
 			log.Info("NodeLabelPolicy not found, cleaning up labels from all nodes", "policyName", req.Name)
 			// Pass nil since policy is already deleted and we only need to clean managed-by labels
-			if err := r.handler.CleanupLabelsFromAllNodes(ctx, req.Name, nil); err != nil {
+			released, err := r.handler.CleanupLabelsFromAllNodes(ctx, req.Name, nil)
+			r.recordCleanedUpNodes(nil, req.Name, released)
+			if err != nil {
 				log.Error(err, "Failed to cleanup labels from nodes", "policyName", req.Name)
+				r.recordFailure(nil, req.Name, err)
 				return ctrl.Result{}, err
 			}
+			metrics.DeletePolicy(req.Name)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get NodeLabelPolicy")
@@ -78,11 +91,25 @@ func (r *NodeLabelPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	} else {
 		if containsString(nodeLabelPolicy.Finalizers, finalizerName) {
-			// Pass the policy labels to ensure proper cleanup
-			if err := r.handler.CleanupLabelsFromAllNodes(ctx, nodeLabelPolicy.Name, nodeLabelPolicy.Spec.Labels); err != nil {
-				log.Error(err, "Failed to cleanup labels during deletion")
-				return ctrl.Result{}, err
+			if nodeLabelPolicy.Spec.DeletionPolicy == nlpv1alpha1.DeletionPolicyOrphan {
+				orphaned, err := r.handler.OrphanLabelsOnAllNodes(ctx, nodeLabelPolicy.Name)
+				r.recordOrphanedNodes(nodeLabelPolicy, orphaned)
+				if err != nil {
+					log.Error(err, "Failed to orphan labels during deletion")
+					r.recordFailure(nodeLabelPolicy, nodeLabelPolicy.Name, err)
+					return ctrl.Result{}, err
+				}
+			} else {
+				// Pass the policy labels to ensure proper cleanup
+				released, err := r.handler.CleanupLabelsFromAllNodes(ctx, nodeLabelPolicy.Name, nodeLabelPolicy.Spec.Labels)
+				r.recordCleanedUpNodes(nodeLabelPolicy, nodeLabelPolicy.Name, released)
+				if err != nil {
+					log.Error(err, "Failed to cleanup labels during deletion")
+					r.recordFailure(nodeLabelPolicy, nodeLabelPolicy.Name, err)
+					return ctrl.Result{}, err
+				}
 			}
+			metrics.DeletePolicy(nodeLabelPolicy.Name)
 			nodeLabelPolicy.Finalizers = removeString(nodeLabelPolicy.Finalizers, finalizerName)
 			if err := r.client.Update(ctx, nodeLabelPolicy); err != nil {
 				log.Error(err, "Failed to remove finalizer")
@@ -92,15 +119,44 @@ func (r *NodeLabelPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, nil
 	}
 
+	if nodeLabelPolicy.Spec.Suspend {
+		return r.reconcileSuspended(ctx, nodeLabelPolicy)
+	}
+
 	log.Info("Reconciling NodeLabelPolicy", "policyName", nodeLabelPolicy.Name, "strategy", nodeLabelPolicy.Spec.Strategy)
 
+	phaseStart := time.Now()
+
+	nodeSelector, err := utils.NodeLabelSelector(nodeLabelPolicy.Spec.Selector)
+	if err != nil {
+		log.Error(err, "Invalid node selector", "selector", nodeLabelPolicy.Spec.Selector)
+		return ctrl.Result{}, r.reportFailure(ctx, nodeLabelPolicy, handlers.ReconcileResult{}, err)
+	}
+
 	nodeList := &corev1.NodeList{}
-	if err := r.client.List(ctx, nodeList); err != nil {
+	if err := r.client.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: nodeSelector}); err != nil {
 		log.Error(err, "Failed to list nodes")
 		return ctrl.Result{}, err
 	}
 
-	selectedNodes, err := r.handler.SelectNodes(ctx, nodeList.Items, nodeLabelPolicy.Spec.Strategy)
+	managedByLabelKey := handlers.ManagedByLabelKey(nodeLabelPolicy.Name)
+
+	// Nodes currently labeled by this policy, including those that no longer match the selector
+	labeledNodeList := &corev1.NodeList{}
+	if err := r.client.List(ctx, labeledNodeList, client.HasLabels{managedByLabelKey}); err != nil {
+		log.Error(err, "Failed to list labeled nodes")
+		return ctrl.Result{}, err
+	}
+
+	currentNodeNames := make([]string, len(labeledNodeList.Items))
+	for i, node := range labeledNodeList.Items {
+		currentNodeNames[i] = node.Name
+	}
+
+	metrics.ObserveReconcilePhase(metrics.PhaseList, phaseStart)
+	phaseStart = time.Now()
+
+	selection, err := r.handler.SelectNodes(ctx, nodeList.Items, nodeLabelPolicy, currentNodeNames)
 	if err != nil {
 		log.Error(err, "Failed to select nodes", "strategy", nodeLabelPolicy.Spec.Strategy)
 		return ctrl.Result{}, err
@@ -108,44 +164,253 @@ func (r *NodeLabelPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	log.V(4).Info("Node selection details",
 		"strategy", nodeLabelPolicy.Spec.Strategy.Type,
-		"count", nodeLabelPolicy.Spec.Strategy.Count,
+		"count", handlers.StrategyCount(nodeLabelPolicy.Spec.Strategy).String(),
+		"desiredCount", selection.DesiredCount,
+		"eligibleNodes", selection.EligibleCount,
+		"excludedNodes", selection.Exclusions,
+		"heldUntil", selection.HeldUntil,
+		"selector", nodeSelector.String(),
 		"totalNodes", len(nodeList.Items),
-		"selectedNodes", len(selectedNodes))
+		"selectedNodes", len(selection.Nodes))
+
+	if selection.ToleratedUntil != nil {
+		log.Info("Keeping labels on NotReady nodes within the NotReady toleration",
+			"notReadyToleration", nodeLabelPolicy.Spec.NotReadyToleration.Duration.String(),
+			"toleratedUntil", selection.ToleratedUntil.Format(time.RFC3339))
+	}
 
-	for i, node := range selectedNodes {
+	for i, node := range selection.Nodes {
 		log.V(4).Info("Selected node",
 			"index", i,
 			"nodeName", node.Name,
 			"creationTimestamp", node.CreationTimestamp.Format("2006-01-02T15:04:05Z"))
 	}
 
-	managedByLabelKey := fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, nodeLabelPolicy.Name)
+	result := handlers.ReconcileResult{Selection: selection}
 
-	for _, node := range selectedNodes {
-		if err := r.handler.ApplyLabelsToNode(ctx, &node, nodeLabelPolicy.Spec.Labels, managedByLabelKey); err != nil {
-			log.Error(err, "Failed to apply labels to node", "nodeName", node.Name)
-			return ctrl.Result{}, err
-		}
+	policyConflicts, err := r.handler.DetectPolicyConflicts(ctx, nodeLabelPolicy, selection.Nodes)
+	if err != nil {
+		log.Error(err, "Failed to detect conflicts with other policies")
+		return ctrl.Result{}, r.reportFailure(ctx, nodeLabelPolicy, result, err)
+	}
+	if len(policyConflicts) > 0 {
+		log.Info("Leaving label keys to higher-priority policies",
+			"keys", policyConflicts.Keys(),
+			"winners", policyConflicts.Winners(),
+			"nodes", policyConflicts.NodeNames())
+		r.recorder.Eventf(nodeLabelPolicy, corev1.EventTypeWarning, nlpv1alpha1.ReasonPolicyConflict,
+			"Label keys %v are left to higher-priority policies %v on nodes %v",
+			policyConflicts.Keys(), policyConflicts.Winners(), policyConflicts.NodeNames())
+		result.PolicyConflicts = policyConflicts
 	}
 
-	if err := r.handler.RemoveLabelsFromUnselectedNodes(ctx, nodeList.Items, selectedNodes, managedByLabelKey, nodeLabelPolicy.Spec.Labels); err != nil {
-		log.Error(err, "Failed to remove labels from unselected nodes")
+	plan, err := r.handler.PlanLabelChanges(nodeLabelPolicy, labeledNodeList.Items, selection.Nodes, policyConflicts)
+	if err != nil {
+		log.Error(err, "Failed to plan label changes")
+		return ctrl.Result{}, r.reportFailure(ctx, nodeLabelPolicy, result, err)
+	}
+	result.Plan = plan
+
+	if plan.RollingOut() {
+		log.Info("Holding back label moves beyond the rollout limits",
+			"pendingLabel", plan.PendingLabel,
+			"pendingUnlabel", plan.PendingUnlabel)
+	}
+
+	metrics.ObserveReconcilePhase(metrics.PhaseSelect, phaseStart)
+
+	if nodeLabelPolicy.Spec.DryRun {
+		log.Info("Planned label changes without changing nodes",
+			"nodesToLabel", plan.NodesToLabel(),
+			"nodesToUnlabel", plan.NodesToUnlabel())
+	} else if err := r.executePlan(ctx, nodeLabelPolicy, plan, &result); err != nil {
+		return ctrl.Result{}, r.reportFailure(ctx, nodeLabelPolicy, result, err)
+	}
+
+	phaseStart = time.Now()
+
+	if err := r.handler.UpdatePolicyStatus(ctx, nodeLabelPolicy, result); err != nil {
+		log.Error(err, "Failed to update NodeLabelPolicy status")
 		return ctrl.Result{}, err
 	}
 
-	selectedNodeNames := make([]string, len(selectedNodes))
-	for i, node := range selectedNodes {
-		selectedNodeNames[i] = node.Name
+	metrics.ObserveReconcilePhase(metrics.PhaseStatus, phaseStart)
+	metrics.RecordSelection(nodeLabelPolicy.Name, selection.DesiredCount, selection.EligibleCount, nodeLabelPolicy.Status.SelectedCount)
+
+	log.Info("Successfully reconciled NodeLabelPolicy", "policyName", nodeLabelPolicy.Name, "selectedNodes", selection.NodeNames())
+
+	return ctrl.Result{RequeueAfter: requeueAfter(selection, plan.RollingOut() && !nodeLabelPolicy.Spec.DryRun)}, nil
+}
+
+// requeueAfter returns the periodic reconcile interval, shortened so that a rollout continues with the
+// held back label moves, a tolerated NotReady node is deselected as soon as its toleration expires and
+// the strategy may move the labels of a node as soon as its minimum selection duration expires
+func requeueAfter(selection handlers.NodeSelection, rollingOut bool) time.Duration {
+	interval := constants.ReconcileInterval
+	if rollingOut {
+		interval = constants.RolloutInterval
+	}
+
+	for _, expiry := range []*time.Time{selection.ToleratedUntil, selection.HeldUntil} {
+		if expiry == nil {
+			continue
+		}
+		// Requeue just after the expiry, since a node is tolerated or held up to and excluding it
+		if untilExpiry := time.Until(*expiry) + time.Second; untilExpiry < interval {
+			interval = untilExpiry
+		}
+	}
+	return interval
+}
+
+// reconcileSuspended leaves the nodes of a suspended policy untouched, or releases its labels from all
+// nodes if requested, and reports the suspension in the policy status
+func (r *NodeLabelPolicyReconciler) reconcileSuspended(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Info("NodeLabelPolicy is suspended", "policyName", policy.Name, "releaseOnSuspend", policy.Spec.ReleaseOnSuspend)
+
+	result := handlers.ReconcileResult{}
+
+	if policy.Spec.ReleaseOnSuspend {
+		released, err := r.handler.CleanupLabelsFromAllNodes(ctx, policy.Name, policy.Spec.Labels)
+		for _, node := range released {
+			metrics.RecordLabelsRemoved(policy.Name, node.Name)
+			r.recorder.Eventf(&node, corev1.EventTypeNormal, constants.EventReasonLabelsRemoved,
+				"Removed labels of suspended NodeLabelPolicy %s", policy.Name)
+			r.recorder.Eventf(policy, corev1.EventTypeNormal, constants.EventReasonLabelsRemoved,
+				"Removed labels from node %s", node.Name)
+		}
+		if err != nil {
+			log.Error(err, "Failed to release labels of suspended NodeLabelPolicy")
+			r.recordFailure(policy, policy.Name, err)
+			return ctrl.Result{}, r.reportFailure(ctx, policy, result, err)
+		}
 	}
 
-	if err := r.handler.UpdatePolicyStatus(ctx, nodeLabelPolicy, selectedNodeNames); err != nil {
+	if err := r.handler.UpdatePolicyStatus(ctx, policy, result); err != nil {
 		log.Error(err, "Failed to update NodeLabelPolicy status")
 		return ctrl.Result{}, err
 	}
 
-	log.Info("Successfully reconciled NodeLabelPolicy", "policyName", nodeLabelPolicy.Name, "selectedNodes", selectedNodeNames)
+	metrics.RecordSelection(policy.Name, policy.Status.DesiredCount, policy.Status.EligibleCount, policy.Status.SelectedCount)
+
+	return ctrl.Result{}, nil
+}
+
+// executePlan applies the planned taints and labels and removes them from unselected nodes,
+// recording the conflicting nodes in result
+func (r *NodeLabelPolicyReconciler) executePlan(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, plan handlers.LabelPlan, result *handlers.ReconcileResult) error {
+	log := logf.FromContext(ctx)
+	phaseStart := time.Now()
+
+	for _, planned := range plan.Apply {
+		node := planned.Node
+
+		// Taints are applied first, since they are patched with an optimistic lock on the listed node
+		tainted, err := r.handler.ApplyTaintsToNode(ctx, &node, policy.Name, planned.Taints)
+		if err != nil {
+			log.Error(err, "Failed to apply taints to node", "nodeName", node.Name)
+			r.recordFailure(policy, policy.Name, err)
+			return err
+		}
+		if tainted {
+			r.recorder.Eventf(&node, corev1.EventTypeNormal, constants.EventReasonTaintsApplied,
+				"Applied taints of NodeLabelPolicy %s", policy.Name)
+			r.recorder.Eventf(policy, corev1.EventTypeNormal, constants.EventReasonTaintsApplied,
+				"Applied taints to node %s", node.Name)
+		}
+
+		applied, err := r.handler.ApplyLabelsToNode(ctx, &node, policy.Name, planned.Labels, planned.Annotations)
+		if len(applied.Conflicts) > 0 {
+			log.Info("Took over labels owned by another field manager", "nodeName", node.Name, "conflicts", applied.Conflicts)
+			result.ConflictingNodes = append(result.ConflictingNodes, node.Name)
+			r.recorder.Eventf(&node, corev1.EventTypeWarning, constants.EventReasonLabelsTakenOver,
+				"NodeLabelPolicy %s took over labels owned by other field managers: %s", policy.Name, strings.Join(applied.Conflicts, "; "))
+			r.recorder.Eventf(policy, corev1.EventTypeWarning, constants.EventReasonLabelsTakenOver,
+				"Took over labels owned by other field managers on node %s: %s", node.Name, strings.Join(applied.Conflicts, "; "))
+		}
+		if err != nil {
+			log.Error(err, "Failed to apply labels to node", "nodeName", node.Name)
+			r.recordFailure(policy, policy.Name, err)
+			return err
+		}
+		if applied.Labeled {
+			metrics.RecordLabelsApplied(policy.Name, node.Name)
+		}
+		if applied.Changed {
+			r.recorder.Eventf(&node, corev1.EventTypeNormal, constants.EventReasonLabelsApplied,
+				"Applied labels %s of NodeLabelPolicy %s", labels.Set(planned.Labels).String(), policy.Name)
+			r.recorder.Eventf(policy, corev1.EventTypeNormal, constants.EventReasonLabelsApplied,
+				"Applied labels to node %s", node.Name)
+		}
+	}
+
+	metrics.ObserveReconcilePhase(metrics.PhaseApply, phaseStart)
+	phaseStart = time.Now()
 
-	return ctrl.Result{RequeueAfter: constants.ReconcileInterval}, nil
+	released, err := r.handler.RemoveLabelsFromNodes(ctx, plan.Remove, policy.Name, policy.Spec.Labels)
+	for _, node := range released {
+		metrics.RecordLabelsRemoved(policy.Name, node.Name)
+		r.recorder.Eventf(&node, corev1.EventTypeNormal, constants.EventReasonLabelsRemoved,
+			"Removed labels of NodeLabelPolicy %s", policy.Name)
+		r.recorder.Eventf(policy, corev1.EventTypeNormal, constants.EventReasonLabelsRemoved,
+			"Removed labels from node %s", node.Name)
+	}
+	if err != nil {
+		log.Error(err, "Failed to remove labels from unselected nodes")
+		r.recordFailure(policy, policy.Name, err)
+		return err
+	}
+
+	metrics.ObserveReconcilePhase(metrics.PhaseRemove, phaseStart)
+	return nil
+}
+
+// recordCleanedUpNodes records events for the nodes whose labels were removed because the policy was deleted
+// policy is nil when the policy no longer exists, in which case events are only recorded on the nodes
+func (r *NodeLabelPolicyReconciler) recordCleanedUpNodes(policy *nlpv1alpha1.NodeLabelPolicy, policyName string, nodes []corev1.Node) {
+	for _, node := range nodes {
+		r.recorder.Eventf(&node, corev1.EventTypeNormal, constants.EventReasonLabelsCleanedUp,
+			"Removed labels of deleted NodeLabelPolicy %s", policyName)
+		if policy != nil {
+			r.recorder.Eventf(policy, corev1.EventTypeNormal, constants.EventReasonLabelsCleanedUp,
+				"Removed labels from node %s", node.Name)
+		}
+	}
+}
+
+// recordOrphanedNodes records events for the nodes whose labels were left in place because the policy was deleted
+func (r *NodeLabelPolicyReconciler) recordOrphanedNodes(policy *nlpv1alpha1.NodeLabelPolicy, nodes []corev1.Node) {
+	for _, node := range nodes {
+		r.recorder.Eventf(&node, corev1.EventTypeNormal, constants.EventReasonLabelsOrphaned,
+			"Left labels of deleted NodeLabelPolicy %s in place", policy.Name)
+		r.recorder.Eventf(policy, corev1.EventTypeNormal, constants.EventReasonLabelsOrphaned,
+			"Left labels in place on node %s", node.Name)
+	}
+}
+
+// recordFailure records a warning on the policy and on the node whose labels could not be changed,
+// and counts the failed node update
+// policy is nil when the policy no longer exists, in which case the warning is only recorded on the node
+func (r *NodeLabelPolicyReconciler) recordFailure(policy *nlpv1alpha1.NodeLabelPolicy, policyName string, err error) {
+	var nodeErr *handlers.NodeUpdateError
+	if errors.As(err, &nodeErr) {
+		metrics.RecordNodeUpdateError(policyName, nodeErr.Err)
+		r.recorder.Event(nodeErr.Node, corev1.EventTypeWarning, constants.EventReasonLabelUpdateFailed,
+			fmt.Sprintf("NodeLabelPolicy %s failed to %s node: %v", policyName, nodeErr.Action, nodeErr.Err))
+	}
+	if policy != nil {
+		r.recorder.Event(policy, corev1.EventTypeWarning, constants.EventReasonLabelUpdateFailed, err.Error())
+	}
+}
+
+// reportFailure records a failed reconciliation in the policy status and returns the original error
+func (r *NodeLabelPolicyReconciler) reportFailure(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, result handlers.ReconcileResult, err error) error {
+	result.Err = err
+	if statusErr := r.handler.UpdatePolicyStatus(ctx, policy, result); statusErr != nil {
+		logf.FromContext(ctx).Error(statusErr, "Failed to update NodeLabelPolicy status")
+	}
+	return err
 }
 
 func (r *NodeLabelPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -157,6 +422,13 @@ func (r *NodeLabelPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *NodeLabelPolicyReconciler) nodeToNodeLabelPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	// Drop the churn series of nodes leaving the cluster, so autoscaling does not grow them without bound
+	if !obj.GetDeletionTimestamp().IsZero() {
+		metrics.DeleteNode(obj.GetName())
+	} else if err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), &corev1.Node{}); apierrors.IsNotFound(err) {
+		metrics.DeleteNode(obj.GetName())
+	}
+
 	nodeLabelPolicyList := &nlpv1alpha1.NodeLabelPolicyList{}
 	if err := r.client.List(ctx, nodeLabelPolicyList); err != nil {
 		return []reconcile.Request{}
@@ -173,11 +445,12 @@ func (r *NodeLabelPolicyReconciler) nodeToNodeLabelPolicy(ctx context.Context, o
 	return requests
 }
 
-func NewNodeLabelPolicyReconciler(k8sClient k8s.Client, policyHandler handlers.NodeLabelPolicyHandler, scheme *runtime.Scheme) *NodeLabelPolicyReconciler {
+func NewNodeLabelPolicyReconciler(k8sClient k8s.Client, policyHandler handlers.NodeLabelPolicyHandler, recorder record.EventRecorder, scheme *runtime.Scheme) *NodeLabelPolicyReconciler {
 	return &NodeLabelPolicyReconciler{
-		client:  k8sClient,
-		handler: policyHandler,
-		Scheme:  scheme,
+		client:   k8sClient,
+		handler:  policyHandler,
+		recorder: utils.NewDedupingEventRecorder(recorder, constants.EventDedupWindow),
+		Scheme:   scheme,
 	}
 }
 
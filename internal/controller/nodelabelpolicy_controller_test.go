@@ -19,19 +19,26 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
 	"github.com/jivvon/node-label-controller/internal/constants"
 	"github.com/jivvon/node-label-controller/internal/controller/handlers"
 	"github.com/jivvon/node-label-controller/internal/external/k8s"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -57,7 +64,7 @@ var _ = Describe("NodeLabelPolicy Controller", func() {
 					Spec: nlpv1alpha1.NodeLabelPolicySpec{
 						Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
 							Type:  "oldest",
-							Count: 1,
+							Count: ptr.To(intstr.FromInt32(1)),
 						},
 						Labels: map[string]string{
 							"test-label": "test-value",
@@ -86,6 +93,7 @@ var _ = Describe("NodeLabelPolicy Controller", func() {
 			controllerReconciler := NewNodeLabelPolicyReconciler(
 				client,
 				handler,
+				record.NewFakeRecorder(10),
 				k8sClient.Scheme(),
 			)
 
@@ -117,7 +125,7 @@ var _ = Describe("NodeLabelPolicy Controller", func() {
 					Spec: nlpv1alpha1.NodeLabelPolicySpec{
 						Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
 							Type:  "oldest",
-							Count: 1,
+							Count: ptr.To(intstr.FromInt32(1)),
 						},
 						Labels: map[string]string{
 							"test-label":    "test-value",
@@ -147,6 +155,7 @@ var _ = Describe("NodeLabelPolicy Controller", func() {
 			controllerReconciler := NewNodeLabelPolicyReconciler(
 				client,
 				handler,
+				record.NewFakeRecorder(10),
 				k8sClient.Scheme(),
 			)
 
@@ -194,4 +203,940 @@ var _ = Describe("NodeLabelPolicy Controller", func() {
 			}
 		})
 	})
+
+	Context("When deleting a NodeLabelPolicy resource that orphans its labels", func() {
+		const resourceName = "test-orphan-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource with the Orphan deletion policy")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"orphan-label": "orphan-value",
+					},
+					DeletionPolicy: nlpv1alpha1.DeletionPolicyOrphan,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			nodeList := &corev1.NodeList{}
+			Expect(k8sClient.List(ctx, nodeList)).To(Succeed())
+			for _, node := range nodeList.Items {
+				if _, ok := node.Labels["orphan-label"]; ok {
+					delete(node.Labels, "orphan-label")
+					Expect(k8sClient.Update(ctx, &node)).To(Succeed())
+				}
+			}
+		})
+
+		It("should leave labels in place and only remove the managed-by label", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			orphanedNodes := func() []corev1.Node {
+				nodeList := &corev1.NodeList{}
+				Expect(k8sClient.List(ctx, nodeList)).To(Succeed())
+				var nodes []corev1.Node
+				for _, node := range nodeList.Items {
+					if node.Labels["orphan-label"] == "orphan-value" {
+						nodes = append(nodes, node)
+					}
+				}
+				return nodes
+			}
+
+			labeledNodes := len(orphanedNodes())
+			Expect(labeledNodes).NotTo(BeZero())
+
+			By("Deleting the NodeLabelPolicy")
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying that the labels survive the deletion")
+			nodes := orphanedNodes()
+			Expect(nodes).To(HaveLen(labeledNodes))
+			for _, node := range nodes {
+				Expect(node.Labels).NotTo(HaveKey(fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, resourceName)))
+			}
+
+			err = k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When the NodeLabelPolicy has taints", func() {
+		const resourceName = "test-taint-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+		policyTaint := corev1.Taint{Key: "test-dedicated", Value: "monitoring", Effect: corev1.TaintEffectNoSchedule}
+		foreignTaint := corev1.Taint{Key: "test-foreign", Effect: corev1.TaintEffectPreferNoSchedule}
+
+		BeforeEach(func() {
+			By("adding a taint the policy does not own")
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			node.Spec.Taints = append(node.Spec.Taints, foreignTaint)
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			By("creating the custom resource with taints")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"taint-label": "taint-value",
+					},
+					Taints: []corev1.Taint{policyTaint},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			node.Spec.Taints = nil
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		})
+
+		It("should taint selected nodes and only remove its own taints on deletion", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("taint-label", "taint-value"))
+			Expect(node.Spec.Taints).To(ContainElements(foreignTaint, policyTaint))
+			Expect(node.Annotations).To(HaveKey(handlers.TaintsAnnotationKey(resourceName)))
+
+			By("Deleting the NodeLabelPolicy")
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Spec.Taints).To(ConsistOf(foreignTaint))
+			Expect(node.Annotations).NotTo(HaveKey(handlers.TaintsAnnotationKey(resourceName)))
+		})
+	})
+
+	Context("When a label key is owned by another field manager", func() {
+		const resourceName = "test-takeover-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+
+		BeforeEach(func() {
+			By("setting the label with another field manager")
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels["takeover-label"] = "someone-else"
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			By("creating the custom resource setting the same label key")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"takeover-label": "platform",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			delete(node.Labels, "takeover-label")
+			delete(node.Labels, fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, resourceName))
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		})
+
+		It("should record a warning event naming the field manager and key it took over", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+			recorder := record.NewFakeRecorder(20)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				recorder,
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("takeover-label", "platform"))
+
+			var events []string
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			takenOver := And(
+				HavePrefix(fmt.Sprintf("%s %s", corev1.EventTypeWarning, constants.EventReasonLabelsTakenOver)),
+				ContainSubstring(".metadata.labels.takeover-label"),
+				ContainSubstring("conflict with"),
+			)
+			Expect(events).To(ContainElement(And(takenOver, ContainSubstring("NodeLabelPolicy "+resourceName))))
+			Expect(events).To(ContainElement(And(takenOver, ContainSubstring("on node test-node-1"))))
+		})
+	})
+
+	Context("When the NodeLabelPolicy has annotations", func() {
+		const resourceName = "test-annotation-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+
+		BeforeEach(func() {
+			By("adding an annotation the policy does not own")
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			if node.Annotations == nil {
+				node.Annotations = map[string]string{}
+			}
+			node.Annotations["example.com/owner"] = "someone-else"
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			By("creating the custom resource with annotations")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"annotation-label": "annotation-value",
+					},
+					Annotations: map[string]string{
+						"example.com/owner": "platform",
+						"example.com/team":  "infra",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			delete(node.Annotations, "example.com/owner")
+			delete(node.Annotations, "example.com/team")
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		})
+
+		It("should annotate selected nodes and only remove its own annotations on deletion", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("annotation-label", "annotation-value"))
+			Expect(node.Annotations).To(HaveKeyWithValue("example.com/owner", "someone-else"))
+			Expect(node.Annotations).To(HaveKeyWithValue("example.com/team", "infra"))
+
+			By("Deleting the NodeLabelPolicy")
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Annotations).To(HaveKeyWithValue("example.com/owner", "someone-else"))
+			Expect(node.Annotations).NotTo(HaveKey("example.com/team"))
+		})
+	})
+
+	Context("When a NodeLabelPolicy takes over existing labels", func() {
+		const resourceName = "test-relabel-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+
+		BeforeEach(func() {
+			By("setting a label the policy will take over")
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			node.Labels["relabel-team"] = "infra"
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"relabel-team": "devops",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			delete(node.Labels, "relabel-team")
+			delete(node.Labels, "relabel-workload")
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		})
+
+		It("should restore the prior value of a key removed from the policy", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("relabel-team", "devops"))
+			Expect(node.Annotations).To(HaveKeyWithValue(handlers.LabelsAnnotationKey(resourceName), `{"relabel-team":"infra"}`))
+
+			By("Replacing the policy's labels")
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Labels = map[string]string{"relabel-workload": "monitoring"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("relabel-team", "infra"))
+			Expect(node.Labels).To(HaveKeyWithValue("relabel-workload", "monitoring"))
+			Expect(node.Annotations).To(HaveKeyWithValue(handlers.LabelsAnnotationKey(resourceName), `{"relabel-workload":null}`))
+		})
+
+		It("should restore the prior value when the policy is deleted", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("relabel-team", "devops"))
+
+			By("Deleting the NodeLabelPolicy")
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("relabel-team", "infra"))
+			Expect(node.Labels).NotTo(HaveKey(handlers.ManagedByLabelKey(resourceName)))
+			Expect(node.Annotations).NotTo(HaveKey(handlers.LabelsAnnotationKey(resourceName)))
+		})
+	})
+
+	Context("When the stored selector can not be parsed", func() {
+		It("should report the policy as degraded", func() {
+			policy := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-invalid-selector",
+					Finalizers: []string{constants.FinalizerName},
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Labels: map[string]string{"selector-label": "selector-value"},
+					Selector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "test-pool", Operator: metav1.LabelSelectorOpIn},
+						},
+					},
+				},
+			}
+			statusWriter := &k8sfakes.FakeStatusWriter{}
+			fakeClient := &k8sfakes.FakeClient{}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				policy.DeepCopyInto(obj.(*nlpv1alpha1.NodeLabelPolicy))
+				return nil
+			}
+			fakeClient.StatusReturns(statusWriter)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				fakeClient,
+				handlers.NewNodeLabelPolicyHandler(fakeClient),
+				record.NewFakeRecorder(10),
+				nil,
+			)
+
+			_, err := controllerReconciler.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: policy.Name},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(fakeClient.ListCallCount()).To(BeZero())
+
+			Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			_, obj, _ := statusWriter.UpdateArgsForCall(0)
+			reported := obj.(*nlpv1alpha1.NodeLabelPolicy)
+			Expect(meta.IsStatusConditionTrue(reported.Status.Conditions, nlpv1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(reported.Status.Conditions, nlpv1alpha1.ConditionReady)).To(BeTrue())
+		})
+	})
+
+	Context("When a NotReady node is tolerated", func() {
+		It("should requeue when the toleration expires", func() {
+			Expect(requeueAfter(handlers.NodeSelection{}, false)).To(Equal(constants.ReconcileInterval))
+
+			until := time.Now().Add(time.Minute)
+			Expect(requeueAfter(handlers.NodeSelection{ToleratedUntil: &until}, false)).To(BeNumerically("~", time.Minute, 2*time.Second))
+
+			until = time.Now().Add(2 * constants.ReconcileInterval)
+			Expect(requeueAfter(handlers.NodeSelection{ToleratedUntil: &until}, false)).To(Equal(constants.ReconcileInterval))
+			Expect(requeueAfter(handlers.NodeSelection{ToleratedUntil: &until}, true)).To(Equal(constants.RolloutInterval))
+
+			held := time.Now().Add(2 * time.Second)
+			Expect(requeueAfter(handlers.NodeSelection{ToleratedUntil: &until, HeldUntil: &held}, false)).
+				To(BeNumerically("~", 3*time.Second, time.Second))
+		})
+	})
+
+	Context("When the NodeLabelPolicy has a node selector", func() {
+		const resourceName = "test-selector-resource"
+		const poolLabelKey = "test-pool"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource with a selector")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"selector-label": "selector-value",
+					},
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{poolLabelKey: "selected"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			delete(node.Labels, poolLabelKey)
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		})
+
+		It("should only label nodes matching the selector and clean up nodes leaving the pool", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			By("Reconciling while no node matches the selector")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).NotTo(HaveKey("selector-label"))
+
+			By("Adding the node to the pool")
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[poolLabelKey] = "selected"
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("selector-label", "selector-value"))
+
+			By("Removing the node from the pool")
+			delete(node.Labels, poolLabelKey)
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).NotTo(HaveKey("selector-label"))
+			Expect(node.Labels).NotTo(HaveKey(fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, resourceName)))
+		})
+
+		It("should reject a selector that can not be parsed at admission", func() {
+			policy := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+				{Key: poolLabelKey, Operator: metav1.LabelSelectorOpIn},
+			}
+
+			Expect(k8sClient.Update(ctx, policy)).To(MatchError(ContainSubstring("spec.selector")))
+		})
+	})
+
+	Context("When the NodeLabelPolicy is a dry run", func() {
+		const resourceName = "test-dry-run-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource in dry-run mode")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"dry-run-label": "dry-run-value",
+					},
+					DryRun: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+		})
+
+		It("should report the planned changes without labeling nodes", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).NotTo(HaveKey("dry-run-label"))
+
+			policy := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Plan).NotTo(BeNil())
+			Expect(policy.Status.SelectedNodes).To(BeEmpty())
+		})
+	})
+
+	Context("When the NodeLabelPolicy is suspended", func() {
+		const resourceName = "test-suspend-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name: resourceName,
+		}
+		managedByLabelKey := fmt.Sprintf("%s.%s/managed-by", constants.ManagedByLabelPrefix, resourceName)
+
+		BeforeEach(func() {
+			By("creating the custom resource")
+			resource := &nlpv1alpha1.NodeLabelPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: resourceName,
+				},
+				Spec: nlpv1alpha1.NodeLabelPolicySpec{
+					Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+						Type:  "oldest",
+						Count: ptr.To(intstr.FromInt32(1)),
+					},
+					Labels: map[string]string{
+						"suspend-label": "suspend-value",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &nlpv1alpha1.NodeLabelPolicy{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			}
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			delete(node.Labels, "suspend-label")
+			delete(node.Labels, managedByLabelKey)
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+		})
+
+		It("should leave labels in place while suspended and release them on request", func() {
+			client := k8s.NewClient(k8sClient)
+			handler := handlers.NewNodeLabelPolicyHandler(client)
+
+			controllerReconciler := NewNodeLabelPolicyReconciler(
+				client,
+				handler,
+				record.NewFakeRecorder(10),
+				k8sClient.Scheme(),
+			)
+
+			By("Labeling the node while the policy is active")
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels["suspend-label"] = "suspend-value"
+			node.Labels[managedByLabelKey] = "true"
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			By("Suspending the policy")
+			policy := &nlpv1alpha1.NodeLabelPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).To(HaveKeyWithValue("suspend-label", "suspend-value"))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, nlpv1alpha1.ConditionSuspended)).To(BeTrue())
+
+			By("Releasing the labels of the suspended policy")
+			policy.Spec.ReleaseOnSuspend = true
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "test-node-1"}, node)).To(Succeed())
+			Expect(node.Labels).NotTo(HaveKey("suspend-label"))
+			Expect(node.Labels).NotTo(HaveKey(managedByLabelKey))
+		})
+	})
+})
+
+var _ = Describe("NodeLabelPolicy Webhooks", func() {
+	const resourceName = "test-webhook-resource"
+
+	typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+	AfterEach(func() {
+		policy := &nlpv1alpha1.NodeLabelPolicy{}
+		if err := k8sClient.Get(ctx, typeNamespacedName, policy); err == nil {
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+		}
+	})
+
+	It("should store the defaults of a minimal policy", func() {
+		policy := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{Type: "spread"},
+				Labels:   map[string]string{"webhook-label": " webhook-value "},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		stored := &nlpv1alpha1.NodeLabelPolicy{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, stored)).To(Succeed())
+		Expect(stored.Spec.Strategy.Count).To(Equal(ptr.To(intstr.FromInt32(1))))
+		Expect(stored.Spec.Strategy.TopologyKey).To(Equal(constants.DefaultTopologyKey))
+		Expect(stored.Spec.Strategy.TieBreaker).To(Equal("oldest"))
+		Expect(stored.Spec.Strategy.Stickiness).To(Equal("none"))
+		Expect(stored.Spec.DeletionPolicy).To(Equal(nlpv1alpha1.DeletionPolicyDelete))
+		Expect(stored.Spec.Labels).To(HaveKeyWithValue("webhook-label", "webhook-value"))
+	})
+
+	It("should delete a policy that collides with a policy created while it was being deleted", func() {
+		const otherName = "test-webhook-other-resource"
+
+		client := k8s.NewClient(k8sClient)
+		controllerReconciler := NewNodeLabelPolicyReconciler(
+			client,
+			handlers.NewNodeLabelPolicyHandler(client),
+			record.NewFakeRecorder(10),
+			k8sClient.Scheme(),
+		)
+
+		policy := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels: map[string]string{"webhook-label": "first"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		By("Reconciling to add the finalizer")
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Deleting the policy and creating a colliding policy while the finalizer holds it")
+		Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+
+		other := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: otherName},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels: map[string]string{"webhook-label": "second"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+		})
+
+		By("Reconciling the deletion, which removes the finalizer of the now colliding policy")
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = k8sClient.Get(ctx, typeNamespacedName, policy)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should admit a policy setting another value for a label key of a policy with another priority", func() {
+		const otherName = "test-webhook-priority-resource"
+
+		other := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: otherName},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels:   map[string]string{"webhook-label": "second"},
+				Priority: 10,
+			},
+		}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+		})
+
+		policy := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels: map[string]string{"webhook-label": "first"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		By("Changing the priority to match the other policy")
+		policy.Spec.Priority = 10
+		Expect(k8sClient.Update(ctx, policy)).To(MatchError(ContainSubstring("spec.labels[webhook-label]")))
+	})
+
+	It("should reject a policy with labels in a restricted namespace", func() {
+		policy := &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels: map[string]string{"node-role.kubernetes.io/worker": ""},
+			},
+		}
+
+		err := k8sClient.Create(ctx, policy)
+		Expect(errors.IsInvalid(err)).To(BeTrue())
+	})
 })
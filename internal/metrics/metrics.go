@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the Prometheus metrics exposed by the controller.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "nlp"
+
+// Reconcile phases whose duration is observed
+const (
+	PhaseList   = "list"
+	PhaseSelect = "select"
+	PhaseApply  = "apply"
+	PhaseRemove = "remove"
+	PhaseStatus = "status"
+)
+
+var (
+	desiredNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "policy_desired_nodes",
+		Help:      "Number of nodes the policy's strategy resolved to during the last reconciliation.",
+	}, []string{"policy"})
+
+	eligibleNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "policy_eligible_nodes",
+		Help:      "Number of nodes the policy's strategy could choose from during the last reconciliation.",
+	}, []string{"policy"})
+
+	selectedNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "policy_selected_nodes",
+		Help:      "Number of nodes carrying the policy's labels after the last reconciliation.",
+	}, []string{"policy"})
+
+	labelsApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "labels_applied_total",
+		Help:      "Number of times a node gained a policy's labels.",
+	}, []string{"policy"})
+
+	labelsRemoved = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "labels_removed_total",
+		Help:      "Number of times a policy's labels were removed from a node.",
+	}, []string{"policy"})
+
+	nodeUpdateErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "node_update_errors_total",
+		Help:      "Number of failed node label updates by API error reason.",
+	}, []string{"policy", "reason"})
+
+	reconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_phase_duration_seconds",
+		Help:      "Duration of the phases of a NodeLabelPolicy reconciliation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"phase"})
+
+	nodeLabelChurn = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "node_label_churn_total",
+		Help:      "Number of times a node gained or lost a policy's labels.",
+	}, []string{"policy", "node"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		desiredNodes,
+		eligibleNodes,
+		selectedNodes,
+		labelsApplied,
+		labelsRemoved,
+		nodeUpdateErrors,
+		reconcilePhaseDuration,
+		nodeLabelChurn,
+	)
+}
+
+// RecordSelection records the node counts of a policy's enforced selection
+func RecordSelection(policyName string, desired, eligible, selected int32) {
+	desiredNodes.WithLabelValues(policyName).Set(float64(desired))
+	eligibleNodes.WithLabelValues(policyName).Set(float64(eligible))
+	selectedNodes.WithLabelValues(policyName).Set(float64(selected))
+}
+
+// RecordLabelsApplied records that a node gained a policy's labels
+func RecordLabelsApplied(policyName, nodeName string) {
+	labelsApplied.WithLabelValues(policyName).Inc()
+	nodeLabelChurn.WithLabelValues(policyName, nodeName).Inc()
+}
+
+// RecordLabelsRemoved records that a policy's labels were removed from a node
+func RecordLabelsRemoved(policyName, nodeName string) {
+	labelsRemoved.WithLabelValues(policyName).Inc()
+	nodeLabelChurn.WithLabelValues(policyName, nodeName).Inc()
+}
+
+// RecordNodeUpdateError records a failed node update by the reason of the API error
+func RecordNodeUpdateError(policyName string, err error) {
+	reason := string(apierrors.ReasonForError(err))
+	if reason == "" {
+		reason = "Unknown"
+	}
+	nodeUpdateErrors.WithLabelValues(policyName, reason).Inc()
+}
+
+// ObserveReconcilePhase records the duration of a reconcile phase that started at start
+func ObserveReconcilePhase(phase string, start time.Time) {
+	reconcilePhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+// DeletePolicy removes the series of a deleted policy
+func DeletePolicy(policyName string) {
+	labels := prometheus.Labels{"policy": policyName}
+	desiredNodes.DeletePartialMatch(labels)
+	eligibleNodes.DeletePartialMatch(labels)
+	selectedNodes.DeletePartialMatch(labels)
+	labelsApplied.DeletePartialMatch(labels)
+	labelsRemoved.DeletePartialMatch(labels)
+	nodeUpdateErrors.DeletePartialMatch(labels)
+	nodeLabelChurn.DeletePartialMatch(labels)
+}
+
+// DeleteNode removes the churn series of a node that left the cluster
+func DeleteNode(nodeName string) {
+	nodeLabelChurn.DeletePartialMatch(prometheus.Labels{"node": nodeName})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMetrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metrics Suite")
+}
+
+var _ = Describe("Metrics", func() {
+	const policyName = "test-policy"
+
+	AfterEach(func() {
+		DeletePolicy(policyName)
+	})
+
+	It("should record the node counts of a selection", func() {
+		RecordSelection(policyName, 3, 4, 2)
+
+		Expect(testutil.ToFloat64(desiredNodes.WithLabelValues(policyName))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(eligibleNodes.WithLabelValues(policyName))).To(Equal(4.0))
+		Expect(testutil.ToFloat64(selectedNodes.WithLabelValues(policyName))).To(Equal(2.0))
+	})
+
+	It("should count applied and removed labels as churn of the node", func() {
+		RecordLabelsApplied(policyName, "node-a")
+		RecordLabelsRemoved(policyName, "node-a")
+		RecordLabelsApplied(policyName, "node-b")
+
+		Expect(testutil.ToFloat64(labelsApplied.WithLabelValues(policyName))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(labelsRemoved.WithLabelValues(policyName))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(nodeLabelChurn.WithLabelValues(policyName, "node-a"))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(nodeLabelChurn.WithLabelValues(policyName, "node-b"))).To(Equal(1.0))
+	})
+
+	It("should count node update errors by API error reason", func() {
+		RecordNodeUpdateError(policyName, apierrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, "node-a"))
+		RecordNodeUpdateError(policyName, fmt.Errorf("connection refused"))
+
+		Expect(testutil.ToFloat64(nodeUpdateErrors.WithLabelValues(policyName, "NotFound"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(nodeUpdateErrors.WithLabelValues(policyName, "Unknown"))).To(Equal(1.0))
+	})
+
+	It("should observe the duration of reconcile phases", func() {
+		ObserveReconcilePhase(PhaseSelect, time.Now().Add(-time.Second))
+
+		Expect(testutil.CollectAndCount(reconcilePhaseDuration, "nlp_reconcile_phase_duration_seconds")).To(Equal(1))
+	})
+
+	It("should remove the series of a deleted policy", func() {
+		RecordSelection(policyName, 1, 1, 1)
+		RecordLabelsApplied(policyName, "node-a")
+		RecordSelection("other-policy", 1, 1, 1)
+
+		DeletePolicy(policyName)
+
+		Expect(testutil.CollectAndCount(desiredNodes)).To(Equal(1))
+		Expect(testutil.CollectAndCount(nodeLabelChurn)).To(Equal(0))
+		DeletePolicy("other-policy")
+	})
+
+	It("should remove the churn series of a deleted node", func() {
+		RecordLabelsApplied(policyName, "node-a")
+		RecordLabelsApplied(policyName, "node-b")
+		RecordLabelsApplied("other-policy", "node-a")
+
+		DeleteNode("node-a")
+
+		Expect(testutil.CollectAndCount(nodeLabelChurn)).To(Equal(1))
+		Expect(testutil.ToFloat64(nodeLabelChurn.WithLabelValues(policyName, "node-b"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(labelsApplied.WithLabelValues(policyName))).To(Equal(2.0))
+		DeletePolicy("other-policy")
+	})
+})
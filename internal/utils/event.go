@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// DedupingEventRecorder is an EventRecorder that drops events identical to one recorded
+// for the same object within a window, so periodic reconciliations do not repeat them
+type DedupingEventRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	recorded map[string]time.Time
+}
+
+var _ record.EventRecorder = &DedupingEventRecorder{}
+
+// NewDedupingEventRecorder wraps recorder so identical events are recorded at most once per window
+func NewDedupingEventRecorder(recorder record.EventRecorder, window time.Duration) *DedupingEventRecorder {
+	return &DedupingEventRecorder{
+		recorder: recorder,
+		window:   window,
+		now:      time.Now,
+		recorded: make(map[string]time.Time),
+	}
+}
+
+// Event records an event unless an identical one was recorded for the object within the window
+func (r *DedupingEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.shouldRecord(object, eventtype, reason, message) {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// Eventf is like Event, but uses fmt.Sprintf to construct the message
+func (r *DedupingEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf is like Eventf, but attaches annotations to the event
+func (r *DedupingEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.shouldRecord(object, eventtype, reason, message) {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// shouldRecord remembers the event and reports whether it was not recorded within the window
+func (r *DedupingEventRecorder) shouldRecord(object runtime.Object, eventtype, reason, message string) bool {
+	key := fmt.Sprintf("%s/%s/%s/%s", objectKey(object), eventtype, reason, message)
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for recordedKey, recordedAt := range r.recorded {
+		if now.Sub(recordedAt) >= r.window {
+			delete(r.recorded, recordedKey)
+		}
+	}
+
+	if _, found := r.recorded[key]; found {
+		return false
+	}
+	r.recorded[key] = now
+	return true
+}
+
+// objectKey identifies the object an event is recorded for
+func objectKey(object runtime.Object) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return fmt.Sprintf("%T", object)
+	}
+	return fmt.Sprintf("%T/%s/%s/%s", object, accessor.GetNamespace(), accessor.GetName(), accessor.GetUID())
+}
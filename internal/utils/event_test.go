@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("DedupingEventRecorder", func() {
+	var (
+		fakeRecorder *record.FakeRecorder
+		recorder     *DedupingEventRecorder
+		now          time.Time
+		node         *corev1.Node
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(10)
+		recorder = NewDedupingEventRecorder(fakeRecorder, time.Minute)
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		recorder.now = func() time.Time { return now }
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", UID: "uid-a"}}
+	})
+
+	It("should drop identical events within the window", func() {
+		recorder.Eventf(node, corev1.EventTypeNormal, "LabelsApplied", "Applied labels %s", "env=prod")
+		now = now.Add(30 * time.Second)
+		recorder.Eventf(node, corev1.EventTypeNormal, "LabelsApplied", "Applied labels %s", "env=prod")
+
+		Expect(fakeRecorder.Events).To(HaveLen(1))
+		Expect(<-fakeRecorder.Events).To(Equal("Normal LabelsApplied Applied labels env=prod"))
+	})
+
+	It("should record identical events again after the window", func() {
+		recorder.Event(node, corev1.EventTypeWarning, "LabelUpdateFailed", "connection refused")
+		now = now.Add(time.Minute)
+		recorder.Event(node, corev1.EventTypeWarning, "LabelUpdateFailed", "connection refused")
+
+		Expect(fakeRecorder.Events).To(HaveLen(2))
+	})
+
+	It("should record events that differ in object, reason or message", func() {
+		other := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", UID: "uid-b"}}
+
+		recorder.Event(node, corev1.EventTypeNormal, "LabelsApplied", "Applied labels env=prod")
+		recorder.Event(other, corev1.EventTypeNormal, "LabelsApplied", "Applied labels env=prod")
+		recorder.Event(node, corev1.EventTypeNormal, "LabelsRemoved", "Applied labels env=prod")
+		recorder.Event(node, corev1.EventTypeNormal, "LabelsApplied", "Applied labels env=dev")
+
+		Expect(fakeRecorder.Events).To(HaveLen(4))
+	})
+})
@@ -1,7 +1,13 @@
 package utils
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
 )
 
 // IsNodeReady checks if a node is in Ready state
@@ -21,6 +27,21 @@ func IsNodeReady(node *corev1.Node) bool {
 	return false
 }
 
+// NotReadySince returns when the node's Ready condition last stopped being True
+// It returns false if the node is Ready, and the zero time for a node without a Ready condition
+func NotReadySince(node *corev1.Node) (time.Time, bool) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				return time.Time{}, false
+			}
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+
+	return time.Time{}, true
+}
+
 // FilterReadyNodes filters a slice of nodes to include only Ready nodes
 func FilterReadyNodes(nodes []corev1.Node) []corev1.Node {
 	var readyNodes []corev1.Node
@@ -33,3 +54,67 @@ func FilterReadyNodes(nodes []corev1.Node) []corev1.Node {
 
 	return readyNodes
 }
+
+// FilterEligibleNodes filters a slice of nodes to the Ready nodes passing the eligibility filters
+// NotReady nodes whose names are in tolerated count as Ready. It also counts the nodes each filter
+// excluded, counting a node failing several filters under the first one. A nil eligibility only
+// excludes nodes that are not Ready.
+func FilterEligibleNodes(nodes []corev1.Node, eligibility *nlpv1alpha1.NodeEligibility, tolerated map[string]bool, now time.Time) ([]corev1.Node, nlpv1alpha1.NodeExclusions) {
+	var eligibleNodes []corev1.Node
+	var exclusions nlpv1alpha1.NodeExclusions
+
+	for _, node := range nodes {
+		switch {
+		case !IsNodeReady(&node) && !tolerated[node.Name]:
+			exclusions.NotReady++
+		case eligibility == nil:
+			eligibleNodes = append(eligibleNodes, node)
+		case eligibility.ExcludeUnschedulable && node.Spec.Unschedulable:
+			exclusions.Unschedulable++
+		case hasMatchingTaint(&node, eligibility.ExcludeTaints):
+			exclusions.Tainted++
+		case hasAnyCondition(&node, eligibility.ExcludeConditions):
+			exclusions.Conditions++
+		case eligibility.MinNodeAge != nil && now.Sub(node.CreationTimestamp.Time) < eligibility.MinNodeAge.Duration:
+			exclusions.TooYoung++
+		default:
+			eligibleNodes = append(eligibleNodes, node)
+		}
+	}
+
+	return eligibleNodes, exclusions
+}
+
+// hasMatchingTaint reports whether the node carries a taint matching any of the selectors
+func hasMatchingTaint(node *corev1.Node, selectors []nlpv1alpha1.NodeTaintSelector) bool {
+	for _, selector := range selectors {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == selector.Key && (selector.Effect == "" || taint.Effect == selector.Effect) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasAnyCondition reports whether the node reports any of the condition types with status True
+func hasAnyCondition(node *corev1.Node, conditionTypes []corev1.NodeConditionType) bool {
+	for _, conditionType := range conditionTypes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NodeLabelSelector converts a policy's label selector into a labels.Selector
+// A nil selector matches all nodes
+func NodeLabelSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+
+	return metav1.LabelSelectorAsSelector(selector)
+}
@@ -2,11 +2,15 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
 )
 
 func TestUtils(t *testing.T) {
@@ -180,4 +184,158 @@ var _ = Describe("Node Utils", func() {
 			Expect(filtered).To(BeEmpty())
 		})
 	})
+
+	Describe("NotReadySince", func() {
+		It("should report a Ready node as not NotReady", func() {
+			node := &corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}}}
+
+			_, notReady := NotReadySince(node)
+			Expect(notReady).To(BeFalse())
+		})
+
+		It("should return the last transition of the Ready condition of a NotReady node", func() {
+			transition := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			node := &corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, LastTransitionTime: metav1.NewTime(transition)},
+			}}}
+
+			since, notReady := NotReadySince(node)
+			Expect(notReady).To(BeTrue())
+			Expect(since).To(Equal(transition))
+		})
+
+		It("should report a node without a Ready condition as NotReady since the zero time", func() {
+			since, notReady := NotReadySince(&corev1.Node{})
+			Expect(notReady).To(BeTrue())
+			Expect(since.IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("FilterEligibleNodes", func() {
+		var now time.Time
+
+		newNode := func(name string, age time.Duration, conditions ...corev1.NodeCondition) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+				Status: corev1.NodeStatus{
+					Conditions: append([]corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+					}, conditions...),
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should only exclude nodes that are not Ready without eligibility filters", func() {
+			ready := newNode("ready", time.Hour)
+			notReady := newNode("not-ready", time.Hour)
+			notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+			cordoned := newNode("cordoned", time.Hour)
+			cordoned.Spec.Unschedulable = true
+
+			eligible, exclusions := FilterEligibleNodes([]corev1.Node{ready, notReady, cordoned}, nil, nil, now)
+			Expect(eligible).To(HaveLen(2))
+			Expect(exclusions).To(Equal(nlpv1alpha1.NodeExclusions{NotReady: 1}))
+		})
+
+		It("should exclude nodes failing each filter and count them", func() {
+			cordoned := newNode("cordoned", time.Hour)
+			cordoned.Spec.Unschedulable = true
+			tainted := newNode("tainted", time.Hour)
+			tainted.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+			preferTainted := newNode("prefer-tainted", time.Hour)
+			preferTainted.Spec.Taints = []corev1.Taint{{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule}}
+			pressured := newNode("pressured", time.Hour, corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue})
+			relieved := newNode("relieved", time.Hour, corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse})
+			young := newNode("young", time.Minute)
+
+			eligibility := &nlpv1alpha1.NodeEligibility{
+				ExcludeUnschedulable: true,
+				ExcludeTaints: []nlpv1alpha1.NodeTaintSelector{
+					{Key: "dedicated"},
+					{Key: "spot", Effect: corev1.TaintEffectNoSchedule},
+				},
+				ExcludeConditions: []corev1.NodeConditionType{corev1.NodeMemoryPressure, corev1.NodeDiskPressure},
+				MinNodeAge:        &metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			eligible, exclusions := FilterEligibleNodes(
+				[]corev1.Node{cordoned, tainted, preferTainted, pressured, relieved, young}, eligibility, nil, now)
+
+			names := make([]string, len(eligible))
+			for i, node := range eligible {
+				names[i] = node.Name
+			}
+			Expect(names).To(Equal([]string{"prefer-tainted", "relieved"}))
+			Expect(exclusions).To(Equal(nlpv1alpha1.NodeExclusions{
+				Unschedulable: 1,
+				Tainted:       1,
+				Conditions:    1,
+				TooYoung:      1,
+			}))
+		})
+
+		It("should keep tolerated NotReady nodes", func() {
+			notReady := newNode("not-ready", time.Hour)
+			notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+
+			eligible, exclusions := FilterEligibleNodes([]corev1.Node{notReady}, nil, map[string]bool{"not-ready": true}, now)
+			Expect(eligible).To(HaveLen(1))
+			Expect(exclusions).To(Equal(nlpv1alpha1.NodeExclusions{}))
+		})
+
+		It("should count a node failing several filters under the first one", func() {
+			node := newNode("cordoned-and-young", time.Minute)
+			node.Spec.Unschedulable = true
+
+			_, exclusions := FilterEligibleNodes([]corev1.Node{node}, &nlpv1alpha1.NodeEligibility{
+				ExcludeUnschedulable: true,
+				MinNodeAge:           &metav1.Duration{Duration: time.Hour},
+			}, nil, now)
+			Expect(exclusions).To(Equal(nlpv1alpha1.NodeExclusions{Unschedulable: 1}))
+		})
+	})
+
+	Describe("NodeLabelSelector", func() {
+		It("should match all nodes for a nil selector", func() {
+			selector, err := NodeLabelSelector(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(labels.Set{"any": "label"})).To(BeTrue())
+			Expect(selector.Empty()).To(BeTrue())
+		})
+
+		It("should match labels and expressions", func() {
+			selector, err := NodeLabelSelector(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"node-role": "worker"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "kubernetes.io/arch",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"amd64"},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.Matches(labels.Set{"node-role": "worker", "kubernetes.io/arch": "amd64"})).To(BeTrue())
+			Expect(selector.Matches(labels.Set{"node-role": "worker", "kubernetes.io/arch": "arm64"})).To(BeFalse())
+			Expect(selector.Matches(labels.Set{"kubernetes.io/arch": "amd64"})).To(BeFalse())
+		})
+
+		It("should return an error for an invalid selector", func() {
+			_, err := NodeLabelSelector(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "kubernetes.io/arch",
+						Operator: "Unknown",
+					},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// LabelTemplateData is the data label value templates are rendered with
+type LabelTemplateData struct {
+	// Node is the node the labels are rendered for
+	Node TemplateNode
+
+	// Index is the number the policy assigned the node among the selected nodes, kept while the node stays selected
+	Index int
+}
+
+// TemplateNode is the view of a node available to label value templates
+type TemplateNode struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// IsLabelTemplate reports whether a label value is a template to be rendered per node
+func IsLabelTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+// RenderLabelTemplates returns the labels with their templated values rendered for the node at the given index
+// Values that are not templates are kept as they are, and a rendered value must be a valid label value
+func RenderLabelTemplates(labels map[string]string, node *corev1.Node, index int) (map[string]string, error) {
+	data := LabelTemplateData{
+		Node: TemplateNode{
+			Name:        node.Name,
+			Labels:      node.Labels,
+			Annotations: node.Annotations,
+		},
+		Index: index,
+	}
+
+	rendered := make(map[string]string, len(labels))
+	for key, value := range labels {
+		if !IsLabelTemplate(value) {
+			rendered[key] = value
+			continue
+		}
+
+		renderedValue, err := renderLabelTemplate(key, value, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered[key] = renderedValue
+	}
+
+	return rendered, nil
+}
+
+// ValidateLabelTemplate parses a label value template and renders it for a sample node without labels,
+// so templates that can not be rendered are rejected before they reach any node
+func ValidateLabelTemplate(key, value string) error {
+	_, err := renderLabelTemplate(key, value, LabelTemplateData{Node: TemplateNode{Name: "node"}})
+	return err
+}
+
+// renderLabelTemplate renders the template of a label value and checks the result is a valid label value
+// Missing map keys render as an empty string.
+func renderLabelTemplate(key, value string, data LabelTemplateData) (string, error) {
+	tmpl, err := template.New(key).Option("missingkey=zero").Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid template for label %s: %w", key, err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render label %s for node %s: %w", key, data.Node.Name, err)
+	}
+
+	result := strings.TrimSpace(rendered.String())
+	if msgs := validation.IsValidLabelValue(result); len(msgs) > 0 {
+		return "", fmt.Errorf("label %s rendered to %q for node %s: %s", key, result, data.Node.Name, strings.Join(msgs, "; "))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Label templates", func() {
+	var node *corev1.Node
+
+	BeforeEach(func() {
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-a",
+				Labels: map[string]string{
+					"topology.kubernetes.io/zone": "zone-a",
+					"pool":                        "gpu",
+				},
+			},
+		}
+	})
+
+	Describe("RenderLabelTemplates", func() {
+		It("should render templated values and keep static values", func() {
+			labels, err := RenderLabelTemplates(map[string]string{
+				"shard":       "{{ .Index }}",
+				"zone":        `{{ index .Node.Labels "topology.kubernetes.io/zone" }}`,
+				"role":        "{{ .Node.Labels.pool }}-worker",
+				"node":        "{{ .Node.Name }}",
+				"environment": "production",
+			}, node, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(labels).To(Equal(map[string]string{
+				"shard":       "2",
+				"zone":        "zone-a",
+				"role":        "gpu-worker",
+				"node":        "node-a",
+				"environment": "production",
+			}))
+		})
+
+		It("should render missing labels as an empty value", func() {
+			labels, err := RenderLabelTemplates(map[string]string{"rack": "{{ .Node.Labels.rack }}"}, node, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labels).To(HaveKeyWithValue("rack", ""))
+		})
+
+		It("should return an error when a value renders to an invalid label value", func() {
+			_, err := RenderLabelTemplates(map[string]string{"zone": "zone {{ .Index }}"}, node, 0)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`label zone rendered to "zone 0" for node node-a`))
+		})
+	})
+
+	Describe("ValidateLabelTemplate", func() {
+		It("should accept a template that renders", func() {
+			Expect(ValidateLabelTemplate("zone", `{{ index .Node.Labels "topology.kubernetes.io/zone" }}`)).To(Succeed())
+		})
+
+		It("should reject a template that does not parse", func() {
+			Expect(ValidateLabelTemplate("shard", "{{ .Index ")).To(MatchError(ContainSubstring("invalid template for label shard")))
+		})
+
+		It("should reject a template referring to unknown fields", func() {
+			Expect(ValidateLabelTemplate("shard", "{{ .Rank }}")).To(MatchError(ContainSubstring("failed to render label shard")))
+		})
+	})
+})
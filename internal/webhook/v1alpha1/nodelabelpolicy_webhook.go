@@ -0,0 +1,484 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 implements the admission webhooks for the nlp.lento.dev/v1alpha1 API.
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/constants"
+	"github.com/jivvon/node-label-controller/internal/external/k8s"
+	"github.com/jivvon/node-label-controller/internal/utils"
+)
+
+// nodelabelpolicylog is for logging in this package.
+var nodelabelpolicylog = logf.Log.WithName("nodelabelpolicy-resource")
+
+// restrictedLabelDomains are the label namespaces reserved for Kubernetes components
+var restrictedLabelDomains = []string{"kubernetes.io", "k8s.io"}
+
+// SetupNodeLabelPolicyWebhookWithManager registers the webhooks for NodeLabelPolicy in the manager.
+func SetupNodeLabelPolicyWebhookWithManager(mgr ctrl.Manager, k8sClient k8s.Client) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nlpv1alpha1.NodeLabelPolicy{}).
+		WithValidator(NewNodeLabelPolicyCustomValidator(k8sClient)).
+		WithDefaulter(&NodeLabelPolicyCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nlp-lento-dev-v1alpha1-nodelabelpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=nlp.lento.dev,resources=nodelabelpolicies,verbs=create;update,versions=v1alpha1,name=mnodelabelpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NodeLabelPolicyCustomDefaulter fills in the defaults the controller would otherwise assume,
+// so the stored policy shows exactly how it is enforced.
+type NodeLabelPolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &NodeLabelPolicyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type NodeLabelPolicy.
+func (d *NodeLabelPolicyCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*nlpv1alpha1.NodeLabelPolicy)
+	if !ok {
+		return fmt.Errorf("expected a NodeLabelPolicy object but got %T", obj)
+	}
+	nodelabelpolicylog.Info("Defaulting for NodeLabelPolicy", "name", policy.GetName())
+
+	defaultSpec(&policy.Spec)
+
+	return nil
+}
+
+// defaultSpec sets the spec fields that are left empty to the values the controller would otherwise assume
+func defaultSpec(spec *nlpv1alpha1.NodeLabelPolicySpec) {
+	defaultStrategy(&spec.Strategy)
+
+	if rollout := spec.Rollout; rollout != nil {
+		if rollout.MaxSurge == nil {
+			rollout.MaxSurge = ptr.To(intstr.FromInt32(constants.DefaultMaxSurge))
+		}
+		if rollout.MaxUnavailable == nil {
+			rollout.MaxUnavailable = ptr.To(intstr.FromInt32(constants.DefaultMaxUnavailable))
+		}
+	}
+
+	if spec.DeletionPolicy == "" {
+		spec.DeletionPolicy = constants.DefaultDeletionPolicy
+	}
+
+	for key, value := range spec.Labels {
+		spec.Labels[key] = strings.TrimSpace(value)
+	}
+}
+
+// defaultStrategy sets the strategy fields that apply to the strategy type and are left empty
+func defaultStrategy(strategy *nlpv1alpha1.NodeLabelPolicyStrategy) {
+	if strategy.Type == "" {
+		strategy.Type = constants.DefaultStrategyType
+	}
+	if strategy.Count == nil {
+		strategy.Count = ptr.To(intstr.FromInt32(constants.DefaultCount))
+	}
+	if strategy.Count.Type == intstr.String && strategy.Rounding == "" {
+		strategy.Rounding = constants.DefaultRounding
+	}
+	if (strategy.Type == "spread" || strategy.Type == "score") && strategy.TopologyKey == "" {
+		strategy.TopologyKey = constants.DefaultTopologyKey
+	}
+	if strategy.Type == "spread" {
+		if strategy.TieBreaker == "" {
+			strategy.TieBreaker = constants.DefaultTieBreaker
+		}
+	}
+	if strategy.Stickiness == "" {
+		strategy.Stickiness = constants.DefaultStickiness
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-nlp-lento-dev-v1alpha1-nodelabelpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=nlp.lento.dev,resources=nodelabelpolicies,verbs=create;update,versions=v1alpha1,name=vnodelabelpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NodeLabelPolicyCustomValidator rejects NodeLabelPolicies whose labels or taints can not be applied to nodes
+// or collide with the labels of another policy with the same priority. Label keys shared with a policy of a
+// different priority are admitted, since the controller keeps the higher-priority value on shared nodes.
+type NodeLabelPolicyCustomValidator struct {
+	client k8s.Client
+}
+
+var _ webhook.CustomValidator = &NodeLabelPolicyCustomValidator{}
+
+// NewNodeLabelPolicyCustomValidator creates a new NodeLabelPolicyCustomValidator
+func NewNodeLabelPolicyCustomValidator(client k8s.Client) *NodeLabelPolicyCustomValidator {
+	return &NodeLabelPolicyCustomValidator{
+		client: client,
+	}
+}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type NodeLabelPolicy.
+func (v *NodeLabelPolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*nlpv1alpha1.NodeLabelPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodeLabelPolicy object but got %T", obj)
+	}
+	nodelabelpolicylog.Info("Validation for NodeLabelPolicy upon creation", "name", policy.GetName())
+
+	return nil, v.validate(ctx, policy)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type NodeLabelPolicy.
+func (v *NodeLabelPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*nlpv1alpha1.NodeLabelPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodeLabelPolicy object for the newObj but got %T", newObj)
+	}
+	oldPolicy, ok := oldObj.(*nlpv1alpha1.NodeLabelPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodeLabelPolicy object for the oldObj but got %T", oldObj)
+	}
+	nodelabelpolicylog.Info("Validation for NodeLabelPolicy upon update", "name", policy.GetName())
+
+	// Updates that leave the spec alone, such as the controller adding or removing its finalizer, are admitted
+	// so that a stored policy failing a rule added since, or colliding with a policy created concurrently,
+	// can still be finalized and deleted. Both specs are compared with their defaults, since the defaulter fills
+	// them in on the update of a policy stored before a default existed.
+	oldSpec, newSpec := oldPolicy.Spec.DeepCopy(), policy.Spec.DeepCopy()
+	defaultSpec(oldSpec)
+	defaultSpec(newSpec)
+	if !policy.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		return nil, nil
+	}
+
+	return nil, v.validate(ctx, policy)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type NodeLabelPolicy.
+func (v *NodeLabelPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid error listing every problem with the policy's labels and taints
+func (v *NodeLabelPolicyCustomValidator) validate(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy) error {
+	labelsPath := field.NewPath("spec", "labels")
+
+	keys := make([]string, 0, len(policy.Spec.Labels))
+	for key := range policy.Spec.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var allErrs field.ErrorList
+	for _, key := range keys {
+		allErrs = append(allErrs, validateLabel(labelsPath.Key(key), key, policy.Spec.Labels[key])...)
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(policy.Spec.Selector,
+		metav1validation.LabelSelectorValidationOptions{}, field.NewPath("spec", "selector"))...)
+	allErrs = append(allErrs, validateAnnotations(field.NewPath("spec", "annotations"), policy.Spec.Annotations)...)
+	allErrs = append(allErrs, validateTaints(field.NewPath("spec", "taints"), policy.Spec.Taints)...)
+	allErrs = append(allErrs, validateCount(field.NewPath("spec", "strategy"), policy.Spec.Strategy)...)
+	allErrs = append(allErrs, validateScoring(field.NewPath("spec", "strategy"), policy.Spec.Strategy)...)
+	allErrs = append(allErrs, validateEligibility(field.NewPath("spec", "eligibility"), policy.Spec.Eligibility, policy.Spec.Taints)...)
+	if toleration := policy.Spec.NotReadyToleration; toleration != nil && toleration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "notReadyToleration"), toleration.Duration.String(), "must not be negative"))
+	}
+	if duration := policy.Spec.MinSelectionDuration; duration != nil && duration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "minSelectionDuration"), duration.Duration.String(), "must not be negative"))
+	}
+	allErrs = append(allErrs, validateRollout(field.NewPath("spec", "rollout"), policy.Spec.Rollout)...)
+
+	collisionErrs, err := v.validateCollisions(ctx, policy, labelsPath, keys)
+	if err != nil {
+		return err
+	}
+	allErrs = append(allErrs, collisionErrs...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(nlpv1alpha1.GroupVersion.WithKind("NodeLabelPolicy").GroupKind(), policy.Name, allErrs)
+}
+
+// validateLabel checks a label against the Kubernetes label syntax and the label namespaces the policy may not use
+// Templated values are checked by rendering them for a sample node
+func validateLabel(path *field.Path, key, value string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, msg := range validation.IsQualifiedName(key) {
+		allErrs = append(allErrs, field.Invalid(path, key, msg))
+	}
+	if utils.IsLabelTemplate(value) {
+		if err := utils.ValidateLabelTemplate(key, value); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, err.Error()))
+		}
+	} else {
+		for _, msg := range validation.IsValidLabelValue(value) {
+			allErrs = append(allErrs, field.Invalid(path, value, msg))
+		}
+	}
+
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return allErrs
+	}
+
+	if strings.HasPrefix(prefix, constants.ManagedByLabelPrefix+".") {
+		allErrs = append(allErrs, field.Forbidden(path,
+			fmt.Sprintf("label keys under the %s.<policy>/ prefix are reserved for the controller", constants.ManagedByLabelPrefix)))
+	}
+	for _, domain := range restrictedLabelDomains {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			allErrs = append(allErrs, field.Forbidden(path,
+				fmt.Sprintf("label keys in the %s namespace are reserved for Kubernetes components", domain)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAnnotations checks annotations against the Kubernetes annotation syntax and rejects
+// keys under the prefix the controller uses for its own annotations
+func validateAnnotations(path *field.Path, annotations map[string]string) field.ErrorList {
+	allErrs := apivalidation.ValidateAnnotations(annotations, path)
+
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prefix, _, found := strings.Cut(key, "/")
+		if found && strings.HasPrefix(prefix, constants.ManagedByLabelPrefix+".") {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key),
+				fmt.Sprintf("annotation keys under the %s.<policy>/ prefix are reserved for the controller", constants.ManagedByLabelPrefix)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCount checks the strategy count is a positive number or a percentage between 1% and 100%,
+// and that the bounds of the resolved count do not contradict each other
+func validateCount(path *field.Path, strategy nlpv1alpha1.NodeLabelPolicyStrategy) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if count := strategy.Count; count != nil {
+		countPath := path.Child("count")
+		switch count.Type {
+		case intstr.Int:
+			if count.IntVal < 1 {
+				allErrs = append(allErrs, field.Invalid(countPath, count.IntVal, "must be greater than 0"))
+			}
+		case intstr.String:
+			percent, err := intstr.GetScaledValueFromIntOrPercent(count, 100, true)
+			switch {
+			case err != nil:
+				allErrs = append(allErrs, field.Invalid(countPath, count.StrVal, `must be an integer or a percentage such as "25%"`))
+			case percent < 1 || percent > 100:
+				allErrs = append(allErrs, field.Invalid(countPath, count.StrVal, "must be a percentage between 1% and 100%"))
+			}
+		}
+	}
+
+	if strategy.MinCount != nil && strategy.MaxCount != nil && *strategy.MinCount > *strategy.MaxCount {
+		allErrs = append(allErrs, field.Invalid(path.Child("minCount"), *strategy.MinCount,
+			fmt.Sprintf("must not be greater than maxCount %d", *strategy.MaxCount)))
+	}
+
+	return allErrs
+}
+
+// validateScoring checks the score strategy has weights to rank nodes by and that the weighted label keys are valid
+func validateScoring(path *field.Path, strategy nlpv1alpha1.NodeLabelPolicyStrategy) field.ErrorList {
+	scorePath := path.Child("score")
+	if strategy.Score == nil {
+		if strategy.Type == "score" {
+			return field.ErrorList{field.Required(scorePath, "the score strategy requires weights to rank nodes by")}
+		}
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for i, labelValue := range strategy.Score.LabelValues {
+		for _, msg := range validation.IsQualifiedName(labelValue.Key) {
+			allErrs = append(allErrs, field.Invalid(scorePath.Child("labelValues").Index(i).Child("key"), labelValue.Key, msg))
+		}
+	}
+	for i, zone := range strategy.Score.Zones {
+		for _, msg := range validation.IsValidLabelValue(zone.Zone) {
+			allErrs = append(allErrs, field.Invalid(scorePath.Child("zones").Index(i).Child("zone"), zone.Zone, msg))
+		}
+	}
+	for i, label := range strategy.Score.Labels {
+		labelPath := scorePath.Child("labels").Index(i)
+		for _, msg := range validation.IsQualifiedName(label.Key) {
+			allErrs = append(allErrs, field.Invalid(labelPath.Child("key"), label.Key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(label.Value) {
+			allErrs = append(allErrs, field.Invalid(labelPath.Child("value"), label.Value, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateEligibility checks the taint keys and minimum node age of the eligibility filters, and rejects
+// excluded taints matching a taint the policy adds, since the policy would then deselect its own nodes
+func validateEligibility(path *field.Path, eligibility *nlpv1alpha1.NodeEligibility, taints []corev1.Taint) field.ErrorList {
+	if eligibility == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for i, selector := range eligibility.ExcludeTaints {
+		for _, msg := range validation.IsQualifiedName(selector.Key) {
+			allErrs = append(allErrs, field.Invalid(path.Child("excludeTaints").Index(i).Child("key"), selector.Key, msg))
+		}
+		for _, taint := range taints {
+			if taint.Key == selector.Key && (selector.Effect == "" || taint.Effect == selector.Effect) {
+				allErrs = append(allErrs, field.Forbidden(path.Child("excludeTaints").Index(i), fmt.Sprintf(
+					"matches the taint %s:%s the policy adds itself, which would make every selected node ineligible",
+					taint.Key, taint.Effect)))
+				break
+			}
+		}
+	}
+	for i, conditionType := range eligibility.ExcludeConditions {
+		if conditionType == "" {
+			allErrs = append(allErrs, field.Required(path.Child("excludeConditions").Index(i), "condition type must not be empty"))
+		}
+	}
+	if eligibility.MinNodeAge != nil && eligibility.MinNodeAge.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minNodeAge"), eligibility.MinNodeAge.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateRollout checks the rollout limits are non-negative numbers or percentages that let labels move
+func validateRollout(path *field.Path, rollout *nlpv1alpha1.NodeLabelPolicyRollout) field.ErrorList {
+	if rollout == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	maxSurge, surgeErrs := validateRolloutLimit(path.Child("maxSurge"), rollout.MaxSurge, constants.DefaultMaxSurge)
+	maxUnavailable, unavailableErrs := validateRolloutLimit(path.Child("maxUnavailable"), rollout.MaxUnavailable, constants.DefaultMaxUnavailable)
+	allErrs = append(allErrs, surgeErrs...)
+	allErrs = append(allErrs, unavailableErrs...)
+
+	if len(allErrs) == 0 && maxSurge == 0 && maxUnavailable == 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxUnavailable"), maxUnavailable,
+			"must not be 0 when maxSurge is 0"))
+	}
+
+	return allErrs
+}
+
+// validateRolloutLimit checks a rollout limit and returns its value scaled to a hundred nodes
+func validateRolloutLimit(path *field.Path, limit *intstr.IntOrString, defaultValue int32) (int, field.ErrorList) {
+	value := intstr.ValueOrDefault(limit, intstr.FromInt32(defaultValue))
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	if err != nil {
+		return 0, field.ErrorList{field.Invalid(path, value.String(), "must be an integer or a percentage")}
+	}
+	if scaled < 0 {
+		return 0, field.ErrorList{field.Invalid(path, value.String(), "must not be negative")}
+	}
+	return scaled, nil
+}
+
+// validateTaints checks taints against the Kubernetes taint syntax and rejects duplicates,
+// since a node carries at most one taint per key and effect
+func validateTaints(path *field.Path, taints []corev1.Taint) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, taint := range taints {
+		taintPath := path.Index(i)
+
+		for _, msg := range validation.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(taintPath.Child("key"), taint.Key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(taint.Value) {
+			allErrs = append(allErrs, field.Invalid(taintPath.Child("value"), taint.Value, msg))
+		}
+
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			allErrs = append(allErrs, field.NotSupported(taintPath.Child("effect"), taint.Effect, []corev1.TaintEffect{
+				corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute,
+			}))
+		}
+
+		for _, other := range taints[:i] {
+			if other.MatchTaint(&taint) {
+				allErrs = append(allErrs, field.Duplicate(taintPath, fmt.Sprintf("%s:%s", taint.Key, taint.Effect)))
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateCollisions rejects label keys another policy sets to a different value with the same priority,
+// since only the policy name would decide which value wins on shared nodes. Keys set by policies with a
+// different priority are not collisions, as the priority decides which value wins.
+// A templated value may render differently per policy even when both set the same template, as .Index is
+// assigned per policy, so a templated key is rejected whenever another policy with the same priority sets it.
+func (v *NodeLabelPolicyCustomValidator) validateCollisions(ctx context.Context, policy *nlpv1alpha1.NodeLabelPolicy, path *field.Path, keys []string) (field.ErrorList, error) {
+	policyList := &nlpv1alpha1.NodeLabelPolicyList{}
+	if err := v.client.List(ctx, policyList); err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("failed to list NodeLabelPolicies: %w", err))
+	}
+
+	var allErrs field.ErrorList
+	for _, key := range keys {
+		for _, other := range policyList.Items {
+			if other.Name == policy.Name || !other.DeletionTimestamp.IsZero() || other.Spec.Priority != policy.Spec.Priority {
+				continue
+			}
+			otherValue, ok := other.Spec.Labels[key]
+			templated := utils.IsLabelTemplate(otherValue) || utils.IsLabelTemplate(policy.Spec.Labels[key])
+			if !ok || (otherValue == policy.Spec.Labels[key] && !templated) {
+				continue
+			}
+			allErrs = append(allErrs, field.Forbidden(path.Key(key), fmt.Sprintf(
+				"label key is set to %q by NodeLabelPolicy %s with the same priority %d, set a different priority to decide which value wins",
+				otherValue, other.Name, other.Spec.Priority)))
+		}
+	}
+
+	return allErrs, nil
+}
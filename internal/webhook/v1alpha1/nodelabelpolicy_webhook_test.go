@@ -0,0 +1,559 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nlpv1alpha1 "github.com/jivvon/node-label-controller/api/v1alpha1"
+	"github.com/jivvon/node-label-controller/internal/external/k8s/k8sfakes"
+)
+
+func TestWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Webhook Suite")
+}
+
+var _ = Describe("NodeLabelPolicy Webhook", func() {
+	var (
+		ctx            context.Context
+		fakeClient     *k8sfakes.FakeClient
+		validator      *NodeLabelPolicyCustomValidator
+		policy         *nlpv1alpha1.NodeLabelPolicy
+		existingPolicy nlpv1alpha1.NodeLabelPolicy
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		policy = &nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Strategy: nlpv1alpha1.NodeLabelPolicyStrategy{
+					Type:  "oldest",
+					Count: ptr.To(intstr.FromInt32(1)),
+				},
+				Labels: map[string]string{"environment": "production"},
+			},
+		}
+		existingPolicy = nlpv1alpha1.NodeLabelPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing-policy"},
+			Spec: nlpv1alpha1.NodeLabelPolicySpec{
+				Labels: map[string]string{"environment": "production"},
+			},
+		}
+
+		fakeClient = &k8sfakes.FakeClient{}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*nlpv1alpha1.NodeLabelPolicyList).Items = []nlpv1alpha1.NodeLabelPolicy{existingPolicy, *policy}
+			return nil
+		}
+		validator = NewNodeLabelPolicyCustomValidator(fakeClient)
+	})
+
+	Context("When creating or updating NodeLabelPolicy under Validating Webhook", func() {
+		It("should admit a policy with valid labels", func() {
+			policy.Spec.Labels = map[string]string{
+				"environment":            "production",
+				"example.com/team":       "platform",
+				"node.example.com/empty": "",
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should validate updates the same way as creation", func() {
+			oldPolicy := policy.DeepCopy()
+			policy.Spec.Labels = map[string]string{"environment": "production!"}
+
+			_, err := validator.ValidateUpdate(ctx, oldPolicy, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("should admit updates that leave an invalid spec unchanged", func() {
+			policy.Spec.Labels = map[string]string{"kubernetes.io/role": "invalid"}
+			oldPolicy := policy.DeepCopy()
+			policy.Finalizers = []string{"nodelabelpolicy.nlp.lento.dev/finalizer"}
+
+			_, err := validator.ValidateUpdate(ctx, oldPolicy, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.ListCallCount()).To(BeZero())
+		})
+
+		It("should admit updates that only fill in the defaults of a policy stored before them", func() {
+			existingPolicy.Spec.Labels["environment"] = "staging"
+			oldPolicy := policy.DeepCopy()
+			policy.Finalizers = []string{"nodelabelpolicy.nlp.lento.dev/finalizer"}
+			Expect((&NodeLabelPolicyCustomDefaulter{}).Default(ctx, policy)).To(Succeed())
+			Expect(policy.Spec).NotTo(Equal(oldPolicy.Spec))
+
+			_, err := validator.ValidateUpdate(ctx, oldPolicy, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.ListCallCount()).To(BeZero())
+		})
+
+		It("should admit updates of a policy being deleted", func() {
+			existingPolicy.Spec.Labels["environment"] = "staging"
+			oldPolicy := policy.DeepCopy()
+			policy.DeletionTimestamp = ptr.To(metav1.Now())
+			policy.Spec.Priority = 1
+
+			_, err := validator.ValidateUpdate(ctx, oldPolicy, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should admit deletion", func() {
+			policy.Spec.Labels = map[string]string{"kubernetes.io/role": "invalid"}
+
+			_, err := validator.ValidateDelete(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject labels that can not be applied to nodes",
+			func(key, value, message string) {
+				policy.Spec.Labels = map[string]string{key: value}
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("spec.labels[%s]", key)))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid key", "invalid key", "value", "name part must consist of alphanumeric characters"),
+			Entry("invalid key prefix", "Example.com/team", "value", "prefix part a lowercase RFC 1123 subdomain"),
+			Entry("invalid value", "environment", "production!", "a valid label must be an empty string or consist of alphanumeric characters"),
+			Entry("too long value", "environment", string(make([]byte, 64)), "must be no more than 63 characters"),
+			Entry("unparsable template", "shard", "{{ .Index ", "invalid template for label shard"),
+			Entry("template with an unknown field", "shard", "{{ .Rank }}", "failed to render label shard"),
+			Entry("template rendering an invalid value", "shard", "shard {{ .Index }}", `label shard rendered to "shard 0"`),
+			Entry("reserved controller prefix", "nlp.other-policy/managed-by", "true", "reserved for the controller"),
+			Entry("kubernetes.io namespace", "kubernetes.io/role", "worker", "reserved for Kubernetes components"),
+			Entry("kubernetes.io subdomain", "node-role.kubernetes.io/worker", "", "reserved for Kubernetes components"),
+			Entry("k8s.io namespace", "k8s.io/team", "platform", "reserved for Kubernetes components"),
+			Entry("k8s.io subdomain", "node.k8s.io/pool", "gpu", "reserved for Kubernetes components"),
+		)
+
+		It("should admit a valid selector", func() {
+			policy.Spec.Selector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/os": "linux"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "kubernetes.io/arch", Operator: metav1.LabelSelectorOpIn, Values: []string{"amd64"}},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a selector that can not be parsed", func() {
+			policy.Spec.Selector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "a", Operator: metav1.LabelSelectorOpIn},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.selector.matchExpressions[0].values"))
+		})
+
+		It("should admit templated label values", func() {
+			policy.Spec.Labels = map[string]string{
+				"shard": "{{ .Index }}",
+				"zone":  `{{ index .Node.Labels "topology.kubernetes.io/zone" }}`,
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should admit annotations whose values are not valid label values", func() {
+			policy.Spec.Annotations = map[string]string{
+				"example.com/description":   "Reserved for monitoring workloads!",
+				"kubernetes.io/description": "node pool for monitoring",
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject annotations that can not be applied to nodes",
+			func(key, message string) {
+				policy.Spec.Annotations = map[string]string{key: "value"}
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.annotations"))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid key", "invalid key", "name part must consist of alphanumeric characters"),
+			Entry("reserved controller prefix", "nlp.other-policy/taints", "reserved for the controller"),
+		)
+
+		It("should admit valid taints", func() {
+			policy.Spec.Taints = []corev1.Taint{
+				{Key: "dedicated", Value: "monitoring", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "monitoring", Effect: corev1.TaintEffectNoExecute},
+				{Key: "example.com/reserved", Effect: corev1.TaintEffectPreferNoSchedule},
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject taints that can not be applied to nodes",
+			func(taints []corev1.Taint, path, message string) {
+				policy.Spec.Taints = taints
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(path))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid key", []corev1.Taint{{Key: "invalid key", Effect: corev1.TaintEffectNoSchedule}},
+				"spec.taints[0].key", "name part must consist of alphanumeric characters"),
+			Entry("invalid value", []corev1.Taint{{Key: "dedicated", Value: "monitoring!", Effect: corev1.TaintEffectNoSchedule}},
+				"spec.taints[0].value", "a valid label must be an empty string or consist of alphanumeric characters"),
+			Entry("missing effect", []corev1.Taint{{Key: "dedicated"}},
+				"spec.taints[0].effect", "Unsupported value"),
+			Entry("unsupported effect", []corev1.Taint{{Key: "dedicated", Effect: "NoWay"}},
+				"spec.taints[0].effect", "Unsupported value"),
+			Entry("duplicate key and effect", []corev1.Taint{
+				{Key: "dedicated", Value: "monitoring", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "logging", Effect: corev1.TaintEffectNoSchedule},
+			}, "spec.taints[1]", "Duplicate value"),
+		)
+
+		It("should admit valid eligibility filters", func() {
+			policy.Spec.Eligibility = &nlpv1alpha1.NodeEligibility{
+				ExcludeUnschedulable: true,
+				ExcludeTaints:        []nlpv1alpha1.NodeTaintSelector{{Key: "node.kubernetes.io/disk-pressure"}},
+				ExcludeConditions:    []corev1.NodeConditionType{corev1.NodeMemoryPressure},
+				MinNodeAge:           &metav1.Duration{Duration: 10 * time.Minute},
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject invalid eligibility filters",
+			func(eligibility *nlpv1alpha1.NodeEligibility, path, message string) {
+				policy.Spec.Eligibility = eligibility
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(path))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("invalid taint key", &nlpv1alpha1.NodeEligibility{ExcludeTaints: []nlpv1alpha1.NodeTaintSelector{{Key: "invalid key"}}},
+				"spec.eligibility.excludeTaints[0].key", "name part must consist of alphanumeric characters"),
+			Entry("empty condition type", &nlpv1alpha1.NodeEligibility{ExcludeConditions: []corev1.NodeConditionType{""}},
+				"spec.eligibility.excludeConditions[0]", "condition type must not be empty"),
+			Entry("negative minimum node age", &nlpv1alpha1.NodeEligibility{MinNodeAge: &metav1.Duration{Duration: -time.Minute}},
+				"spec.eligibility.minNodeAge", "must not be negative"),
+		)
+
+		It("should reject excluded taints matching a taint the policy adds", func() {
+			policy.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "monitoring", Effect: corev1.TaintEffectNoSchedule}}
+			policy.Spec.Eligibility = &nlpv1alpha1.NodeEligibility{
+				ExcludeTaints: []nlpv1alpha1.NodeTaintSelector{
+					{Key: "dedicated", Effect: corev1.TaintEffectNoExecute},
+					{Key: "dedicated"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.eligibility.excludeTaints[1]"))
+			Expect(err.Error()).To(ContainSubstring("matches the taint dedicated:NoSchedule the policy adds itself"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.eligibility.excludeTaints[0]"))
+		})
+
+		It("should reject a negative NotReady toleration", func() {
+			policy.Spec.NotReadyToleration = &metav1.Duration{Duration: -time.Minute}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.notReadyToleration"))
+		})
+
+		It("should admit percentage counts with bounds", func() {
+			policy.Spec.Strategy.Count = ptr.To(intstr.FromString("100%"))
+			policy.Spec.Strategy.MinCount = ptr.To[int32](2)
+			policy.Spec.Strategy.MaxCount = ptr.To[int32](2)
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject invalid counts",
+			func(strategy nlpv1alpha1.NodeLabelPolicyStrategy, path, message string) {
+				policy.Spec.Strategy = strategy
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(path))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("zero count", nlpv1alpha1.NodeLabelPolicyStrategy{Count: ptr.To(intstr.FromInt32(0))},
+				"spec.strategy.count", "must be greater than 0"),
+			Entry("negative count", nlpv1alpha1.NodeLabelPolicyStrategy{Count: ptr.To(intstr.FromInt32(-2))},
+				"spec.strategy.count", "must be greater than 0"),
+			Entry("unparsable percentage", nlpv1alpha1.NodeLabelPolicyStrategy{Count: ptr.To(intstr.FromString("half"))},
+				"spec.strategy.count", `must be an integer or a percentage such as "25%"`),
+			Entry("percentage without a sign", nlpv1alpha1.NodeLabelPolicyStrategy{Count: ptr.To(intstr.FromString("25"))},
+				"spec.strategy.count", `must be an integer or a percentage such as "25%"`),
+			Entry("zero percentage", nlpv1alpha1.NodeLabelPolicyStrategy{Count: ptr.To(intstr.FromString("0%"))},
+				"spec.strategy.count", "must be a percentage between 1% and 100%"),
+			Entry("percentage above 100%", nlpv1alpha1.NodeLabelPolicyStrategy{Count: ptr.To(intstr.FromString("150%"))},
+				"spec.strategy.count", "must be a percentage between 1% and 100%"),
+			Entry("minCount above maxCount", nlpv1alpha1.NodeLabelPolicyStrategy{MinCount: ptr.To[int32](3), MaxCount: ptr.To[int32](2)},
+				"spec.strategy.minCount", "must not be greater than maxCount 2"),
+		)
+
+		It("should admit a score strategy with weights", func() {
+			policy.Spec.Strategy.Type = "score"
+			policy.Spec.Strategy.Score = &nlpv1alpha1.NodeScoring{
+				CPU:         2,
+				Age:         -1,
+				LabelValues: []nlpv1alpha1.NodeLabelValueWeight{{Key: "example.com/bandwidth", Weight: 1}},
+				Zones:       []nlpv1alpha1.NodeZoneWeight{{Zone: "zone-a", Weight: 3}},
+				Labels:      []nlpv1alpha1.NodeLabelWeight{{Key: "example.com/ingress", Value: "allowed", Weight: 1}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject invalid score weights",
+			func(score *nlpv1alpha1.NodeScoring, path, message string) {
+				policy.Spec.Strategy.Type = "score"
+				policy.Spec.Strategy.Score = score
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(path))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("missing weights", nil, "spec.strategy.score", "the score strategy requires weights to rank nodes by"),
+			Entry("invalid label value key", &nlpv1alpha1.NodeScoring{LabelValues: []nlpv1alpha1.NodeLabelValueWeight{{Key: "invalid key", Weight: 1}}},
+				"spec.strategy.score.labelValues[0].key", "name part must consist of alphanumeric characters"),
+			Entry("invalid zone", &nlpv1alpha1.NodeScoring{Zones: []nlpv1alpha1.NodeZoneWeight{{Zone: "zone a", Weight: 1}}},
+				"spec.strategy.score.zones[0].zone", "a valid label must be an empty string or consist of alphanumeric characters"),
+			Entry("invalid label value", &nlpv1alpha1.NodeScoring{Labels: []nlpv1alpha1.NodeLabelWeight{{Key: "example.com/ingress", Value: "not allowed", Weight: 1}}},
+				"spec.strategy.score.labels[0].value", "a valid label must be an empty string or consist of alphanumeric characters"),
+		)
+
+		It("should reject a negative minimum selection duration", func() {
+			policy.Spec.MinSelectionDuration = &metav1.Duration{Duration: -time.Minute}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.minSelectionDuration"))
+		})
+
+		DescribeTable("should reject invalid rollout limits",
+			func(rollout *nlpv1alpha1.NodeLabelPolicyRollout, path, message string) {
+				policy.Spec.Rollout = rollout
+
+				_, err := validator.ValidateCreate(ctx, policy)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(path))
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("negative surge", &nlpv1alpha1.NodeLabelPolicyRollout{MaxSurge: ptr.To(intstr.FromInt32(-1))},
+				"spec.rollout.maxSurge", "must not be negative"),
+			Entry("invalid unavailability", &nlpv1alpha1.NodeLabelPolicyRollout{MaxUnavailable: ptr.To(intstr.FromString("half"))},
+				"spec.rollout.maxUnavailable", "must be an integer or a percentage"),
+			Entry("no surge and no unavailability", &nlpv1alpha1.NodeLabelPolicyRollout{
+				MaxSurge:       ptr.To(intstr.FromString("0%")),
+				MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+			}, "spec.rollout.maxUnavailable", "must not be 0 when maxSurge is 0"),
+		)
+
+		It("should admit rollout limits given as percentages", func() {
+			policy.Spec.Rollout = &nlpv1alpha1.NodeLabelPolicyRollout{
+				MaxSurge:       ptr.To(intstr.FromString("25%")),
+				MaxUnavailable: ptr.To(intstr.FromString("10%")),
+			}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a label key another policy sets to a different value with the same priority", func() {
+			existingPolicy.Spec.Labels["environment"] = "staging"
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.labels[environment]"))
+			Expect(err.Error()).To(ContainSubstring("existing-policy"))
+		})
+
+		It("should admit a label key another policy sets to a different value with another priority", func() {
+			existingPolicy.Spec.Labels["environment"] = "staging"
+			policy.Spec.Priority = 10
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should admit a label key another policy sets to the same value", func() {
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a templated label key another policy sets with the same priority", func() {
+			existingPolicy.Spec.Labels["shard"] = "{{ .Index }}"
+			policy.Spec.Labels["shard"] = "{{ .Index }}"
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.labels[shard]"))
+			Expect(err.Error()).To(ContainSubstring("existing-policy"))
+		})
+
+		It("should ignore policies that are being deleted", func() {
+			existingPolicy.Spec.Labels["environment"] = "staging"
+			existingPolicy.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return an internal error when policies can not be listed", func() {
+			fakeClient.ListStub = nil
+			fakeClient.ListReturns(fmt.Errorf("connection refused"))
+
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(apierrors.IsInternalError(err)).To(BeTrue())
+		})
+	})
+
+	Context("When creating or updating NodeLabelPolicy under Defaulting Webhook", func() {
+		var defaulter *NodeLabelPolicyCustomDefaulter
+
+		BeforeEach(func() {
+			defaulter = &NodeLabelPolicyCustomDefaulter{}
+		})
+
+		It("should fill in the defaults of a minimal policy", func() {
+			policy.Spec.Strategy = nlpv1alpha1.NodeLabelPolicyStrategy{}
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Strategy).To(Equal(nlpv1alpha1.NodeLabelPolicyStrategy{
+				Type:       "oldest",
+				Count:      ptr.To(intstr.FromInt32(1)),
+				Stickiness: "none",
+			}))
+		})
+
+		It("should default the deletion policy to Delete", func() {
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.DeletionPolicy).To(Equal("Delete"))
+		})
+
+		It("should keep an Orphan deletion policy", func() {
+			policy.Spec.DeletionPolicy = "Orphan"
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.DeletionPolicy).To(Equal("Orphan"))
+		})
+
+		It("should default the topology key of the score strategy", func() {
+			policy.Spec.Strategy.Type = "score"
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Strategy.TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+			Expect(policy.Spec.Strategy.TieBreaker).To(BeEmpty())
+		})
+
+		It("should default the limits of a rollout", func() {
+			policy.Spec.Rollout = &nlpv1alpha1.NodeLabelPolicyRollout{}
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Rollout).To(Equal(&nlpv1alpha1.NodeLabelPolicyRollout{
+				MaxSurge:       ptr.To(intstr.FromInt32(1)),
+				MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+			}))
+		})
+
+		It("should default the rounding of percentage counts", func() {
+			policy.Spec.Strategy.Count = ptr.To(intstr.FromString("25%"))
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Strategy.Rounding).To(Equal("up"))
+		})
+
+		It("should default the topology key and tie-breaker of the spread strategy", func() {
+			policy.Spec.Strategy.Type = "spread"
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Strategy.TopologyKey).To(Equal("topology.kubernetes.io/zone"))
+			Expect(policy.Spec.Strategy.TieBreaker).To(Equal("oldest"))
+		})
+
+		It("should not override fields that are set", func() {
+			policy.Spec.Strategy = nlpv1alpha1.NodeLabelPolicyStrategy{
+				Type:        "spread",
+				Count:       ptr.To(intstr.FromString("50%")),
+				Rounding:    "down",
+				TopologyKey: "topology.kubernetes.io/region",
+				TieBreaker:  "newest",
+				Stickiness:  "sticky",
+			}
+			expected := policy.Spec.Strategy.DeepCopy()
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Strategy).To(Equal(*expected))
+		})
+
+		It("should trim surrounding whitespace from label values", func() {
+			policy.Spec.Labels = map[string]string{
+				"environment": " production\n",
+				"team":        "platform",
+			}
+
+			Expect(defaulter.Default(ctx, policy)).To(Succeed())
+
+			Expect(policy.Spec.Labels).To(Equal(map[string]string{
+				"environment": "production",
+				"team":        "platform",
+			}))
+		})
+	})
+})